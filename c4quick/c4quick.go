@@ -0,0 +1,146 @@
+package c4quick
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/YKhan142008/c4-solver/generate"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Property-based testing of Connect Four code against random game positions.
+//
+// `Check` runs a property over random game prefixes and, when the property fails, shrinks the
+// failing game to a shorter one that still fails before reporting it:
+//
+//	c4quick.Check(t, func(g c4quick.Game) bool {
+//		col := engine.BestMove(g.Position)
+//		return !g.Position.IsPlayable(col) || ...
+//	}, nil)
+//
+// `Game` also implements `testing/quick.Generator`, for use with `quick.Check` without shrinking.
+
+// A random game prefix. Generated games never contain a winning move.
+type Game struct {
+	// The 1-based column digits of the moves played.
+	Moves string
+	// The position reached after playing `Moves`.
+	Position *position.Position
+}
+
+type Config struct {
+	// The number of games to test. Defaults to 100.
+	Count int
+	// The largest number of moves in a game. Defaults to `position.BoardSize`.
+	MaxPlies int
+	// The random seed. Defaults to a seed based on the current time, reported on failure.
+	Seed int64
+	// Conditions that generated and shrunk positions must meet.
+	Filters []generate.Filter
+}
+
+// Implements `testing/quick.Generator` with games of up to `size` moves.
+func (Game) Generate(rng *rand.Rand, size int) reflect.Value {
+	plies := rng.Intn(min(size, position.BoardSize) + 1)
+	moves, p, err := generate.NewGenerator(rng.Int63()).RandomGame(plies)
+	if err != nil {
+		moves, p = "", position.NewPosition()
+	}
+	return reflect.ValueOf(Game{Moves: moves, Position: p})
+}
+
+// Checks that a property holds for random games, reporting the smallest failing game found.
+//
+// # Arguments
+//
+// * `t`: The test reporting failures.
+// * `property`: Returns false if the property does not hold for a game. It may be called
+// many times while shrinking, and must not modify the game's position.
+// * `config`: Options, or nil for the defaults.
+func Check(t testing.TB, property func(g Game) bool, config *Config) {
+	t.Helper()
+	if config == nil {
+		config = &Config{}
+	}
+	count := config.Count
+	if count <= 0 {
+		count = 100
+	}
+	max_plies := config.MaxPlies
+	if max_plies <= 0 || max_plies > position.BoardSize {
+		max_plies = position.BoardSize
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	g := generate.NewGenerator(seed)
+	for i := 0; i < count; i++ {
+		moves, p, err := g.RandomPosition(rng.Intn(max_plies+1), config.Filters...)
+		if err != nil {
+			// Some ply counts have no position matching the filters
+			continue
+		}
+		if game := (Game{Moves: moves, Position: p}); !property(game) {
+			shrunk := shrink(game, property, config.Filters)
+			t.Errorf("property failed after %d games (seed %d) for moves %q, shrunk from %q:\n%s",
+				i+1, seed, shrunk.Moves, moves, shrunk.Position)
+			return
+		}
+	}
+}
+
+// Repeatedly replaces a failing game with a smaller one which still fails, until none of the
+// candidate reductions fail.
+func shrink(game Game, property func(g Game) bool, filters []generate.Filter) Game {
+	for {
+		reduced := false
+		for _, moves := range shrink_candidates(game.Moves) {
+			candidate, ok := valid_game(moves, filters)
+			if ok && !property(candidate) {
+				game = candidate
+				reduced = true
+				break
+			}
+		}
+		if !reduced {
+			return game
+		}
+	}
+}
+
+// Lists smaller move sequences, from the most to the least aggressive reduction: shorter
+// prefixes, then the sequence without one move of each player, which keeps the same player
+// to move.
+func shrink_candidates(moves string) []string {
+	var candidates []string
+	for n := len(moves) / 2; n < len(moves); n++ {
+		candidates = append(candidates, moves[:n])
+	}
+	for i := 0; i+1 < len(moves); i++ {
+		for j := i + 1; j < len(moves); j += 2 {
+			candidates = append(candidates, moves[:i]+moves[i+1:j]+moves[j+1:])
+		}
+	}
+	return candidates
+}
+
+func valid_game(moves string, filters []generate.Filter) (Game, bool) {
+	p := position.NewPosition()
+	if moves != "" {
+		var err error
+		if p, err = position.PositionFromMoves(moves); err != nil {
+			return Game{}, false
+		}
+	}
+	for _, filter := range filters {
+		if !filter(p) {
+			return Game{}, false
+		}
+	}
+	return Game{Moves: moves, Position: p}, true
+}
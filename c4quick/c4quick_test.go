@@ -0,0 +1,53 @@
+package c4quick_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4quick"
+)
+
+// Records the failures reported by `c4quick.Check` instead of failing the test.
+type recording_tb struct {
+	testing.TB
+	errors []string
+}
+
+func (self *recording_tb) Helper() {}
+
+func (self *recording_tb) Errorf(format string, args ...any) {
+	self.errors = append(self.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCheckShrinksFailingGames(t *testing.T) {
+	tb := &recording_tb{TB: t}
+	c4quick.Check(tb, func(g c4quick.Game) bool {
+		return len(g.Moves) < 10
+	}, &c4quick.Config{Seed: 1})
+
+	if len(tb.errors) != 1 {
+		t.Fatalf("Check() reported %d failures, want 1", len(tb.errors))
+	}
+	report := tb.errors[0]
+	if !strings.Contains(report, "(seed 1)") {
+		t.Errorf("report %q does not contain the seed", report)
+	}
+	var shrunk, original string
+	if _, err := fmt.Sscanf(report[strings.Index(report, "for moves"):], "for moves %q, shrunk from %q", &shrunk, &original); err != nil {
+		t.Fatalf("cannot parse report %q: %v", report, err)
+	}
+	if len(shrunk) != 10 || len(original) < 10 {
+		t.Errorf("shrunk %q (%d moves) from %q, want exactly 10 moves", shrunk, len(shrunk), original)
+	}
+}
+
+func TestCheckPassingProperty(t *testing.T) {
+	tb := &recording_tb{TB: t}
+	c4quick.Check(tb, func(g c4quick.Game) bool {
+		return g.Position.GetMoves() == len(g.Moves)
+	}, &c4quick.Config{Seed: 1, Count: 20})
+	if len(tb.errors) != 0 {
+		t.Errorf("Check() reported %q, want no failures", tb.errors)
+	}
+}
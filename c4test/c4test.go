@@ -0,0 +1,96 @@
+package c4test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Helpers for writing readable tests against Connect Four positions and analyses.
+//
+// Golden files live under the calling package's testdata directory, with a ".golden"
+// extension. Run the tests with `-c4test.update` to create or rewrite them from the current
+// output. This package registers that flag, so it should only be imported from tests.
+
+var update = flag.Bool("c4test.update", false, "rewrite golden files with the current output")
+
+// Builds a position from a visual grid, one string per row from the top.
+//
+// Rows use the format of `position.PositionFromBoardString`: 'x' for the player to move,
+// 'o' for the opponent, '.' for empty cells, and any other character is ignored.
+//
+//	p := c4test.Grid(t,
+//		".......",
+//		".......",
+//		".......",
+//		".......",
+//		"...o...",
+//		"..xxo..",
+//	)
+func Grid(t testing.TB, rows ...string) *position.Position {
+	t.Helper()
+	p, err := position.PositionFromBoardString(strings.Join(rows, "\n"))
+	if err != nil {
+		t.Fatalf("invalid grid: %v", err)
+	}
+	return p
+}
+
+// Builds a position from a sequence of moves in either `position.Notation`.
+func Moves(t testing.TB, move_sequence string) *position.Position {
+	t.Helper()
+	if move_sequence == "" {
+		return position.NewPosition()
+	}
+	p, err := position.PositionFromMoves(move_sequence)
+	if err != nil {
+		t.Fatalf("invalid moves %q: %v", move_sequence, err)
+	}
+	return p
+}
+
+// Compares output with the golden file testdata/`name`.golden.
+func Golden(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -c4test.update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s does not match the golden file %s\n--- got\n%s\n--- want\n%s", name, path, got, want)
+	}
+}
+
+// Compares the rendering of a board with a golden file.
+func GoldenBoard(t testing.TB, name string, p *position.Position) {
+	t.Helper()
+	Golden(t, name, []byte(p.String()))
+}
+
+// Compares a value, e.g. an analysis result, with a golden file as indented JSON.
+func GoldenJSON(t testing.TB, name string, v any) {
+	t.Helper()
+	got, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("cannot encode %s: %v", name, err)
+	}
+	Golden(t, name, append(got, '\n'))
+}
@@ -0,0 +1,74 @@
+package c4test_test
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4test"
+)
+
+// Records the failures reported by the golden helpers instead of failing the test.
+type recording_tb struct {
+	testing.TB
+	errors []string
+}
+
+func (self *recording_tb) Helper() {}
+
+func (self *recording_tb) Errorf(format string, args ...any) {
+	self.errors = append(self.errors, fmt.Sprintf(format, args...))
+}
+
+func (self *recording_tb) Fatalf(format string, args ...any) {
+	self.errors = append(self.errors, fmt.Sprintf(format, args...))
+}
+
+func TestGoldenBoard(t *testing.T) {
+	c4test.GoldenBoard(t, "board", c4test.Moves(t, "4453"))
+}
+
+func TestGoldenJSON(t *testing.T) {
+	p := c4test.Moves(t, "4453")
+	c4test.GoldenJSON(t, "position", struct {
+		Moves    string `json:"moves"`
+		Plies    int    `json:"plies"`
+		Playable []bool `json:"playable"`
+	}{"4453", p.GetMoves(), []bool{p.IsPlayable(0), p.IsPlayable(3)}})
+}
+
+func TestGoldenMismatch(t *testing.T) {
+	tb := &recording_tb{TB: t}
+	c4test.Golden(tb, "board", []byte("not the board\n"))
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "does not match the golden file") {
+		t.Errorf("Golden() reported %q, want one mismatch", tb.errors)
+	}
+}
+
+func TestGoldenMissingFile(t *testing.T) {
+	tb := &recording_tb{TB: t}
+	c4test.Golden(tb, "missing", []byte("output\n"))
+	if len(tb.errors) == 0 || !strings.Contains(tb.errors[0], "-c4test.update") {
+		t.Errorf("Golden() reported %q, want a hint to run with -c4test.update", tb.errors)
+	}
+}
+
+func TestGoldenUpdate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := flag.Set("c4test.update", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer flag.Set("c4test.update", "false")
+
+	c4test.Golden(t, "nested/output", []byte("output\n"))
+	got, err := os.ReadFile(filepath.Join("testdata", "nested", "output.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "output\n" {
+		t.Errorf("golden file contains %q, want %q", got, "output\n")
+	}
+}
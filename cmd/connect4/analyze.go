@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// Prints the score of every column of a position, and optionally a softmax policy over them.
+func run_analyze(args []string) error {
+	flags := new_flag_set("analyze")
+	weak := flags.Bool("weak", false, "only compute whether each move wins, draws or loses")
+	policy := flags.Bool("policy", false, "also print a probability for each column")
+	temperature := flags.Float64("temperature", 1, "softmax temperature of the policy, 0 keeps only the best moves")
+	search_log := flags.String("search-log", "", "write a log of the root search decisions to this file, for search-replay")
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	p, err := parse_position(flags.Args())
+	if err != nil {
+		return err
+	}
+	if p.IsWonPosition() {
+		return parse_error(fmt.Errorf("position is already won"))
+	}
+
+	var options solver.Options
+	if *search_log != "" {
+		f, err := os.Create(*search_log)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w := bufio.NewWriter(f)
+		defer w.Flush()
+		options.SearchLog = w
+	}
+
+	s := solver.NewSolverWithOptions(options)
+	scores := s.Analyze(p, *weak)
+
+	fields := make([]string, len(scores))
+	for col, score := range scores {
+		fields[col] = fmt.Sprint(score)
+	}
+	fmt.Println(strings.Join(fields, " "))
+
+	if *policy {
+		for col, probability := range solver.Policy(scores, *temperature) {
+			fields[col] = fmt.Sprintf("%.4f", probability)
+		}
+		fmt.Println(strings.Join(fields, " "))
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"time"
+
+	"github.com/YKhan142008/c4-solver/generate"
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// Accumulates benchmark results so the compiler cannot discard the timed calls.
+var bench_sink uint64
+
+// Times each win detection implementation over the same random positions, and optionally
+// the solver over a reproducible set of positions.
+func run_bench(args []string) error {
+	flags := new_flag_set("bench")
+	count := flags.Int("positions", 100000, "number of random positions")
+	rounds := flags.Int("rounds", 20, "passes over the positions per implementation")
+	seed := flags.Int64("seed", 1, "random seed for generating positions")
+	arch_report := flags.Bool("arch-report", false, "also report the platform, build selections and solver throughput")
+	solves := flags.Int("solves", 200, "number of random positions solved by the solver benchmark")
+	record := flags.String("record", "", "write per-position solver results to this JSON file")
+	compare := flags.String("compare", "", "solve the positions of this JSON file and report regressions against it")
+	threshold := flags.Float64("threshold", 10, "percentage increase in nodes or time reported as a regression")
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	rng := rand.New(rand.NewSource(*seed))
+	positions := random_game_positions(rng, *count)
+
+	if *arch_report {
+		fmt.Printf("platform: %s/%s, %d CPUs, %s\n", runtime.GOOS, runtime.GOARCH, runtime.NumCPU(), runtime.Version())
+		fmt.Printf("population count: %s\n", position.PopCount)
+		fmt.Printf("transposition table entries: %d (%d bytes)\n", solver.DefaultTableSize, solver.DefaultTableSize*solver.TableEntryBytes)
+	}
+
+	fmt.Printf("win detection selected at build time: %s\n", position.WinDetection)
+	fmt.Printf("%-8s %16s %16s\n", "detector", "winning ns/op", "won ns/op")
+
+	var reference []uint64
+	for _, detector := range position.WinDetectors {
+		// Checks that every implementation agrees before timing them
+		results := make([]uint64, len(positions))
+		for i, p := range positions {
+			results[i] = detector.WinningPositions(p.GetBoard(), p.GetMask())
+			if detector.IsWon(p.GetBoard()) {
+				results[i] |= 1 << 63
+			}
+		}
+		if reference == nil {
+			reference = results
+		}
+		for i := range results {
+			if results[i] != reference[i] {
+				return check_error(fmt.Errorf("%s disagrees with %s on position %d", detector.Name, position.WinDetectors[0].Name, i))
+			}
+		}
+
+		start := time.Now()
+		for r := 0; r < *rounds; r++ {
+			for _, p := range positions {
+				bench_sink ^= detector.WinningPositions(p.GetBoard(), p.GetMask())
+			}
+		}
+		winning := time.Since(start)
+
+		start = time.Now()
+		for r := 0; r < *rounds; r++ {
+			for _, p := range positions {
+				if detector.IsWon(p.GetBoard()) {
+					bench_sink++
+				}
+			}
+		}
+		won := time.Since(start)
+
+		ops := float64(*rounds * len(positions))
+		fmt.Printf("%-8s %16.2f %16.2f\n", detector.Name,
+			float64(winning.Nanoseconds())/ops, float64(won.Nanoseconds())/ops)
+	}
+
+	if !*arch_report && *record == "" && *compare == "" {
+		return nil
+	}
+
+	var baseline *bench_results
+	var moves []string
+	if *compare != "" {
+		var err error
+		if baseline, err = read_bench_results(*compare); err != nil {
+			return err
+		}
+		for _, r := range baseline.Positions {
+			moves = append(moves, r.Moves)
+		}
+	} else {
+		var err error
+		if moves, err = bench_solver_positions(*seed, *solves); err != nil {
+			return err
+		}
+	}
+
+	results, err := bench_solver(moves)
+	if err != nil {
+		return err
+	}
+	var nodes uint64 = 0
+	var elapsed int64 = 0
+	for _, r := range results.Positions {
+		nodes += r.Nodes
+		elapsed += r.Nanoseconds
+	}
+	fmt.Printf("solver: %d positions, %d nodes in %v, %.0f nodes/s\n", len(results.Positions), nodes,
+		time.Duration(elapsed).Round(time.Millisecond), float64(nodes)/time.Duration(elapsed).Seconds())
+
+	if *record != "" {
+		if err := write_bench_results(*record, results); err != nil {
+			return err
+		}
+	}
+	if baseline != nil {
+		return compare_bench_results(baseline, results, *threshold)
+	}
+	return nil
+}
+
+// Generates reproducible mid-game positions, late enough to solve quickly.
+func bench_solver_positions(seed int64, count int) ([]string, error) {
+	rng := rand.New(rand.NewSource(seed))
+	g := generate.NewGenerator(seed)
+	moves := make([]string, count)
+	for i := range moves {
+		var err error
+		if moves[i], _, err = g.RandomPosition(18+rng.Intn(10), generate.NoImmediateWin); err != nil {
+			return nil, err
+		}
+	}
+	return moves, nil
+}
+
+// Solves every position from an empty transposition table, recording nodes and time.
+func bench_solver(moves []string) (*bench_results, error) {
+	results := &bench_results{
+		Platform:  fmt.Sprintf("%s/%s %s", runtime.GOOS, runtime.GOARCH, runtime.Version()),
+		Positions: make([]bench_record, len(moves)),
+	}
+	s := solver.NewSolver()
+	for i, m := range moves {
+		p, err := parse_position([]string{m})
+		if err != nil {
+			return nil, err
+		}
+		s.Reset()
+		start := time.Now()
+		score := s.Solve(p, false)
+		results.Positions[i] = bench_record{
+			Moves:       m,
+			Score:       score,
+			Nodes:       s.GetNodeCount(),
+			Nanoseconds: time.Since(start).Nanoseconds(),
+		}
+	}
+	return results, nil
+}
+
+// Generates positions by playing random legal moves for a random number of plies.
+// Games stop early once a player completes a 4-alignment.
+func random_game_positions(rng *rand.Rand, count int) []position.Position {
+	positions := make([]position.Position, count)
+	for i := range positions {
+		p := position.NewPosition()
+		plies := rng.Intn(position.BoardSize)
+		for p.GetMoves() < plies && !p.IsWonPosition() {
+			col := rng.Intn(position.W)
+			if p.IsPlayable(col) {
+				p.Play(col)
+			}
+		}
+		positions[i] = *p
+	}
+	return positions
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Timings below this are too noisy to report as regressions on their own.
+const bench_min_time time.Duration = time.Millisecond
+
+// Solver benchmark results, stored by `bench -record` and read back by `bench -compare`.
+type bench_results struct {
+	Platform  string         `json:"platform"`
+	Positions []bench_record `json:"positions"`
+}
+
+type bench_record struct {
+	Moves       string `json:"moves"`
+	Score       int    `json:"score"`
+	Nodes       uint64 `json:"nodes"`
+	Nanoseconds int64  `json:"ns"`
+}
+
+func read_bench_results(path string) (*bench_results, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var results bench_results
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, parse_error(fmt.Errorf("%s: %w", path, err))
+	}
+	return &results, nil
+}
+
+func write_bench_results(path string, results *bench_results) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Prints the positions whose node count or time grew by more than `threshold` percent, and
+// any score change, followed by the totals. Time is only compared for positions taking at least
+// `bench_min_time`.
+//
+// # Errors
+//
+// Returns a check error if any score changed or any position regressed.
+func compare_bench_results(old *bench_results, new *bench_results, threshold float64) error {
+	fmt.Printf("comparing with %s\n", old.Platform)
+
+	regressions, changed := 0, 0
+	var old_nodes, new_nodes uint64 = 0, 0
+	var old_time, new_time int64 = 0, 0
+	for i, o := range old.Positions {
+		n := new.Positions[i]
+		old_nodes += o.Nodes
+		new_nodes += n.Nodes
+		old_time += o.Nanoseconds
+		new_time += n.Nanoseconds
+
+		if n.Score != o.Score {
+			changed++
+			fmt.Printf("%s: score changed from %d to %d\n", o.Moves, o.Score, n.Score)
+			continue
+		}
+		node_change := percent_change(float64(o.Nodes), float64(n.Nodes))
+		time_change := percent_change(float64(o.Nanoseconds), float64(n.Nanoseconds))
+		if time.Duration(n.Nanoseconds) < bench_min_time {
+			time_change = 0
+		}
+		if node_change > threshold || time_change > threshold {
+			regressions++
+			fmt.Printf("%s: nodes %d -> %d (%+.1f%%), time %v -> %v (%+.1f%%)\n", o.Moves,
+				o.Nodes, n.Nodes, node_change, time.Duration(o.Nanoseconds), time.Duration(n.Nanoseconds), time_change)
+		}
+	}
+
+	fmt.Printf("total: nodes %d -> %d (%+.1f%%), time %v -> %v (%+.1f%%)\n",
+		old_nodes, new_nodes, percent_change(float64(old_nodes), float64(new_nodes)),
+		time.Duration(old_time).Round(time.Millisecond), time.Duration(new_time).Round(time.Millisecond),
+		percent_change(float64(old_time), float64(new_time)))
+
+	if changed > 0 || regressions > 0 {
+		return check_error(fmt.Errorf("%d score changes and %d regressions beyond %.1f%%", changed, regressions, threshold))
+	}
+	return nil
+}
+
+func percent_change(old float64, new float64) float64 {
+	if old == 0 {
+		return 0
+	}
+	return (new - old) / old * 100
+}
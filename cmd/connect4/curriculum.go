@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/YKhan142008/c4-solver/internal/curriculum"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// Prints a graded curriculum of puzzles, one lesson per tactical motif, with their solutions.
+func run_curriculum(args []string) error {
+	flags := new_flag_set("curriculum")
+	puzzles := flags.Int("puzzles", 5, "number of puzzles per lesson")
+	attempts := flags.Int("attempts", 1000, "number of puzzles examined before giving up on rare motifs")
+	seed := flags.Int64("seed", 1, "random seed for mining the puzzles")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+	if *puzzles < 1 {
+		return usage_error(fmt.Errorf("invalid puzzle count %d: expected at least 1", *puzzles))
+	}
+
+	for i, lesson := range curriculum.Build(solver.NewSolver(), *seed, *puzzles, *attempts) {
+		fmt.Printf("lesson %d: %s (%d puzzles)\n", i+1, lesson.Topic, len(lesson.Puzzles))
+		for _, puzzle := range lesson.Puzzles {
+			fmt.Printf("  %-28s  solution %s  wins in %d moves\n",
+				notation.Format(puzzle.Moves), notation.Column(puzzle.Solution), (puzzle.Plies+1)/2)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Exit codes. These are stable, so scripts can rely on them.
+const (
+	exit_ok int = 0
+	// Any failure not covered by a more specific code, such as an I/O error.
+	exit_internal int = 1
+	// An unknown command, an invalid flag or an invalid flag value.
+	exit_usage int = 2
+	// An invalid position or move sequence.
+	exit_parse int = 3
+	// A search stopped by its budget before finding a result.
+	exit_unsolved int = 4
+	// A self-check failed, e.g. two engines disagreeing in `verify`.
+	exit_check int = 5
+)
+
+// The error output format, selected with the `-format` flag of every command.
+var output_format string = "text"
+
+// An error with the exit code it should produce.
+type cli_error struct {
+	code int
+	err  error
+}
+
+func (e cli_error) Error() string {
+	return e.err.Error()
+}
+
+func (e cli_error) Unwrap() error {
+	return e.err
+}
+
+func usage_error(err error) error {
+	return cli_error{exit_usage, err}
+}
+
+func parse_error(err error) error {
+	return cli_error{exit_parse, err}
+}
+
+func check_error(err error) error {
+	return cli_error{exit_check, err}
+}
+
+// Returns the exit code of an error returned by a command.
+func exit_code(err error) int {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return exit_ok
+	}
+	var e cli_error
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return exit_internal
+}
+
+func exit_kind(code int) string {
+	switch code {
+	case exit_usage:
+		return "usage"
+	case exit_parse:
+		return "parse"
+	case exit_unsolved:
+		return "unsolved"
+	case exit_check:
+		return "check"
+	}
+	return "internal"
+}
+
+// Creates the flag set of a command, with the `-format` flag shared by every command.
+func new_flag_set(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.StringVar(&output_format, "format", "text", "error output format: text, or json for an error envelope on standard output")
+	return flags
+}
+
+func parse_flags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return usage_error(err)
+	}
+	if output_format != "text" && output_format != "json" {
+		return usage_error(fmt.Errorf("invalid format %q: expected text or json", output_format))
+	}
+	return nil
+}
+
+// Reports an error returned by a command in the selected format and exits with its code.
+//
+// The JSON envelope is written to standard output as a single line:
+//
+//	{"error":{"code":3,"kind":"parse","message":"invalid character: character '8' at index 1"}}
+func exit_with_error(err error) {
+	code := exit_code(err)
+	if code == exit_ok {
+		os.Exit(exit_ok)
+	}
+
+	if output_format == "json" {
+		type envelope struct {
+			Code    int    `json:"code"`
+			Kind    string `json:"kind"`
+			Message string `json:"message"`
+		}
+		json.NewEncoder(os.Stdout).Encode(map[string]envelope{
+			"error": {Code: code, Kind: exit_kind(code), Message: err.Error()},
+		})
+	} else {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	os.Exit(code)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/YKhan142008/c4-solver/internal/teach"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// Prints progressively stronger hints towards the best move, up to a given level.
+func run_hint(args []string) error {
+	flags := new_flag_set("hint")
+	notation := notation_flag(flags)
+	level := flags.Int("level", 1, fmt.Sprintf("number of hints to print, from 1 to %d where the last gives the move", teach.Levels))
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+	if *level < 1 || *level > teach.Levels {
+		return usage_error(fmt.Errorf("invalid level %d: expected between 1 and %d", *level, teach.Levels))
+	}
+
+	p, err := parse_position(flags.Args())
+	if err != nil {
+		return err
+	}
+	if p.IsWonPosition() {
+		return parse_error(fmt.Errorf("position is already won"))
+	}
+
+	hints, err := teach.Hints(solver.NewSolver(), p, *notation)
+	if err != nil {
+		return parse_error(err)
+	}
+	for _, hint := range hints[:*level] {
+		fmt.Println(hint)
+	}
+	return nil
+}
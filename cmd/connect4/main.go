@@ -1,5 +1,108 @@
 package main
 
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// A subcommand of the `connect4` executable.
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"analyze":        {"analyze [flags] [moves]", run_analyze},
+	"bench":          {"bench [flags]", run_bench},
+	"curriculum":     {"curriculum [flags]", run_curriculum},
+	"diff":           {"diff [flags] a b", run_diff},
+	"hint":           {"hint [flags] [moves]", run_hint},
+	"openings":       {"openings [flags]", run_openings},
+	"pipe":           {"pipe [flags]", run_pipe},
+	"puzzle":         {"puzzle [flags]", run_puzzle},
+	"render":         {"render [flags]", run_render},
+	"repertoire":     {"repertoire [flags] [moves]", run_repertoire},
+	"sample":         {"sample [flags] [moves]", run_sample},
+	"search-replay":  {"search-replay [flags] file", run_search_replay},
+	"show":           {"show [flags] [moves]", run_show},
+	"transpositions": {"transpositions [flags]", run_transpositions},
+	"verify":         {"verify [flags]", run_verify},
+	"whatif":         {"whatif [flags] [moves]", run_what_if},
+}
+
 func main() {
+	if len(os.Args) < 2 {
+		print_usage()
+		os.Exit(exit_usage)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		print_usage()
+		os.Exit(exit_usage)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		exit_with_error(err)
+	}
+}
+
+func print_usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  connect4 %s\n", commands[name].usage)
+	}
+}
+
+// Parses a position from an optional sequence of moves in either notation, or from a board in
+// the format of `position.PositionFromBoardString`, e.g. for handicap setups.
+// No sequence, or an empty one, gives the initial position.
+func parse_position(args []string) (*position.Position, error) {
+	if len(args) == 0 || args[0] == "" {
+		return position.NewPosition(), nil
+	}
+	if is_board_string(args[0]) {
+		p, err := position.PositionFromBoardString(args[0])
+		if err != nil {
+			return nil, parse_error(err)
+		}
+		if p.IsWonPosition() {
+			return nil, parse_error(fmt.Errorf("position is already won"))
+		}
+		return p, nil
+	}
+	p, err := position.PositionFromMoves(args[0])
+	if err != nil {
+		return nil, parse_error(err)
+	}
+	return p, nil
+}
+
+// Indicates whether a position argument is a board rather than a sequence of moves. Cell
+// characters never appear in either notation.
+func is_board_string(arg string) bool {
+	return strings.ContainsAny(arg, ".oxOX")
+}
 
+// Adds the `-notation` flag selecting how a command writes columns.
+func notation_flag(flags *flag.FlagSet) *position.Notation {
+	notation := position.Digits
+	flags.Func("notation", "column notation of the output: digits or letters", func(name string) error {
+		var err error
+		notation, err = position.ParseNotation(name)
+		return err
+	})
+	return &notation
 }
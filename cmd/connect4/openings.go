@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// Prints the exact result of every opening line up to a given depth, optionally after a
+// given sequence of moves.
+//
+// Opening positions are the hardest to solve, so a full report takes a long time.
+func run_openings(args []string) error {
+	flags := new_flag_set("openings")
+	depth := flags.Int("depth", 2, "number of moves to enumerate")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	if is_board_string(flags.Arg(0)) {
+		return usage_error(fmt.Errorf("openings expects a sequence of moves, not a board"))
+	}
+	root, err := parse_position(flags.Args())
+	if err != nil {
+		return err
+	}
+	prefix := position.Digits.Format(flags.Arg(0))
+	if *depth < 1 || root.GetMoves()+*depth > position.BoardSize {
+		return usage_error(fmt.Errorf("invalid depth %d: expected between 1 and %d", *depth, position.BoardSize-root.GetMoves()))
+	}
+
+	width := max(len(prefix)+*depth, len("moves"))
+	fmt.Printf("%-*s  %-18s  %5s  %12s\n", width, "moves", "result", "score", "ends at move")
+
+	// The table is shared between lines, since sibling openings transpose into each other
+	s := solver.NewSolver()
+	var report func(moves string)
+	report = func(moves string) {
+		p, err := position.PositionFromMoves(moves)
+		if err != nil {
+			// Skips lines ending in a win
+			return
+		}
+
+		score := s.Solve(p, false)
+		end := p.GetMoves() + solver.PliesToEnd(p.GetMoves(), score)
+
+		// Reports the score from the first player's point of view
+		if !p.IsFirstPlayerToMove() {
+			score = -score
+		}
+		result := "draw"
+		if score > 0 {
+			result = "first player wins"
+		} else if score < 0 {
+			result = "second player wins"
+		}
+		fmt.Printf("%-*s  %-18s  %+5d  %12d\n", width, notation.Format(moves), result, score, end)
+
+		if len(moves) < len(prefix)+*depth {
+			for col := 0; col < position.W; col++ {
+				report(moves + string(rune('1'+col)))
+			}
+		}
+	}
+
+	for col := 0; col < position.W; col++ {
+		report(prefix + string(rune('1'+col)))
+	}
+	return nil
+}
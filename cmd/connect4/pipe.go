@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+	"github.com/YKhan142008/c4-solver/telemetry"
+)
+
+// A line read by `pipe`: either a JSON object, or a bare move sequence with default options.
+type pipe_request struct {
+	Moves string `json:"moves"`
+	Weak  bool   `json:"weak"`
+	// Requests a policy over the columns with this softmax temperature.
+	Temperature *float64 `json:"temperature"`
+}
+
+// A line written by `pipe`. `Moves` echoes the request, `Best` is written in the notation chosen
+// on the command line, and `Scores` and `Policy` are indexed by column with a null score for
+// full columns.
+type pipe_response struct {
+	Moves  string    `json:"moves"`
+	Score  *int      `json:"score,omitempty"`
+	Best   string    `json:"best,omitempty"`
+	Scores []*int    `json:"scores,omitempty"`
+	Policy []float64 `json:"policy,omitempty"`
+	Nodes  uint64    `json:"nodes,omitempty"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// Answers one analysis request per line of standard input with one JSON line on standard
+// output, until standard input is closed. Errors are reported in the response, never by exiting.
+func run_pipe(args []string) error {
+	flags := new_flag_set("pipe")
+	telemetry_path := flags.String("telemetry", "", "append solve telemetry as JSON lines to this file")
+	max_memory := flags.Int("max-memory", 0, "cap on the solver's memory in MiB, 0 for the default table size")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	var sink telemetry.Sink = telemetry.Nop{}
+	if *telemetry_path != "" {
+		f, err := os.OpenFile(*telemetry_path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		sink = telemetry.NewJSONLines(f)
+	}
+
+	// Keeps the transposition table between requests
+	s := solver.NewSolverWithOptions(solver.Options{MaxMemoryBytes: *max_memory << 20})
+	encoder := json.NewEncoder(os.Stdout)
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := encoder.Encode(answer_pipe_request(s, sink, *notation, line)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func answer_pipe_request(s *solver.Solver, sink telemetry.Sink, notation position.Notation, line string) pipe_response {
+	var request pipe_request
+	if strings.HasPrefix(line, "{") {
+		if err := json.Unmarshal([]byte(line), &request); err != nil {
+			return pipe_response{Error: err.Error()}
+		}
+	} else {
+		request.Moves = line
+	}
+
+	response := pipe_response{Moves: request.Moves}
+	p, err := parse_position([]string{request.Moves})
+	if err != nil {
+		response.Error = err.Error()
+		return response
+	}
+	if p.IsWonPosition() {
+		response.Error = "position is already won"
+		return response
+	}
+
+	nodes := s.GetNodeCount()
+	probes, hits := s.GetTableStats()
+	start := time.Now()
+	scores := s.Analyze(p, request.Weak)
+	elapsed := time.Since(start)
+	response.Nodes = s.GetNodeCount() - nodes
+
+	// Breaks ties between equally good moves like the other commands, centre first
+	response.Scores = make([]*int, len(scores))
+	playable := 0
+	for _, col := range position.CentreFirst() {
+		if scores[col] == solver.InvalidMove {
+			continue
+		}
+		playable++
+		response.Scores[col] = &scores[col]
+		if response.Score == nil || scores[col] > *response.Score {
+			response.Score = &scores[col]
+			response.Best = notation.Column(col)
+		}
+	}
+	if request.Temperature != nil {
+		response.Policy = solver.Policy(scores, *request.Temperature)
+	}
+
+	new_probes, new_hits := s.GetTableStats()
+	sink.RecordSolve(telemetry.Solve{
+		Moves:       p.GetMoves(),
+		Weak:        request.Weak,
+		Positions:   playable,
+		Nodes:       response.Nodes,
+		Duration:    elapsed,
+		TableProbes: new_probes - probes,
+		TableHits:   new_hits - hits,
+		MemoryBytes: s.Stats().MemoryBytes,
+	})
+	return response
+}
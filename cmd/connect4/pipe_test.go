@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+	"github.com/YKhan142008/c4-solver/telemetry"
+)
+
+func TestPipeBreaksTiesCentreFirst(t *testing.T) {
+	// Columns 1 and 4 both win with a score of 9
+	response := answer_pipe_request(solver.NewSolver(), telemetry.Nop{}, position.Digits, "643366736255126355176217")
+	if response.Error != "" {
+		t.Fatal(response.Error)
+	}
+	if response.Best != "4" || *response.Score != 9 {
+		t.Errorf("best = %q with score %d, want \"4\" with score 9", response.Best, *response.Score)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/YKhan142008/c4-solver/generate"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// The number of moves played before a daily puzzle, late enough for puzzles to be found quickly.
+const puzzle_plies int = 22
+
+// Prints the puzzle of a day: a position where a single move wins. Everyone gets the same
+// puzzle for a given date, and the solution is only printed on request.
+func run_puzzle(args []string) error {
+	flags := new_flag_set("puzzle")
+	date := flags.String("date", "", "day of the puzzle as YYYY-MM-DD, today in UTC by default")
+	solution := flags.Bool("solution", false, "also print the winning move")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	day := time.Now().UTC()
+	if *date != "" {
+		var err error
+		if day, err = time.Parse(time.DateOnly, *date); err != nil {
+			return usage_error(fmt.Errorf("invalid date %q: expected YYYY-MM-DD", *date))
+		}
+	}
+
+	// Seeds the generator with the number of days since the Unix epoch
+	s := solver.NewSolver()
+	g := generate.NewGenerator(day.Unix() / (24 * 60 * 60))
+	moves, p, err := g.RandomPosition(puzzle_plies, generate.UniqueWin(s))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("puzzle of %s: x to play and win\n%s", day.Format(time.DateOnly), p)
+	if *solution {
+		for col, score := range s.Analyze(p, true) {
+			if score > 0 {
+				fmt.Printf("solution: column %s after %s\n", notation.Column(col), notation.Format(moves))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// The size of a cell of a rendered board, and the height of the score row above it, in pixels.
+const (
+	render_cell   int = 60
+	render_margin int = 30
+)
+
+// Renders every position of a file to an image, optionally with the score of each column
+// above the board. Images are named after the moves of the position, or after its line for
+// boards.
+func run_render(args []string) error {
+	flags := new_flag_set("render")
+	positions := flags.String("positions", "-", "file with one position per line, or - for standard input")
+	out := flags.String("out", "", "directory to write the images to, created if needed")
+	// `-format` already selects the error output format of every command
+	format := flags.String("image", "svg", "image format: svg")
+	analysis := flags.Bool("analysis", false, "print the score of every column above the board")
+	weak := flags.Bool("weak", false, "only show whether each move wins, draws or loses")
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return usage_error(fmt.Errorf("render requires an output directory"))
+	}
+	if *format != "svg" {
+		return usage_error(fmt.Errorf("unsupported image format %q: expected svg", *format))
+	}
+
+	var input io.Reader = os.Stdin
+	if *positions != "-" {
+		f, err := os.Open(*positions)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+
+	s := solver.NewSolver()
+	line := 0
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		p, err := parse_position(fields[:1])
+		if err != nil {
+			return parse_error(fmt.Errorf("line %d: %w", line, err))
+		}
+
+		var scores []int
+		if *analysis {
+			scores = s.Analyze(p, *weak)
+		}
+
+		name := fields[0]
+		if is_board_string(name) {
+			name = fmt.Sprintf("line%d", line)
+		}
+		path := filepath.Join(*out, name+"."+*format)
+		if err := write_svg(path, p, scores); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Writes a position as an SVG image, with the first player's discs in red and the second
+// player's in yellow. Scores, if any, are written above their columns.
+func write_svg(path string, p *position.Position, scores []int) error {
+	top := 0
+	if scores != nil {
+		top = render_margin
+	}
+	width, height := position.W*render_cell, top+position.H*render_cell
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", width, height, width, height)
+	fmt.Fprintf(&sb, "<rect x=\"0\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"#1e4fc2\"/>\n", top, width, height-top)
+
+	red := p.FirstPlayerDiscs()
+	for col := 0; col < position.W; col++ {
+		cx := col*render_cell + render_cell/2
+		if scores != nil && scores[col] != solver.InvalidMove {
+			fmt.Fprintf(&sb, "<text x=\"%d\" y=\"%d\" font-family=\"sans-serif\" font-size=\"16\" text-anchor=\"middle\">%+d</text>\n",
+				cx, render_margin*2/3, scores[col])
+		}
+		for row := 0; row < position.H; row++ {
+			bit := uint64(1) << (row + col*(position.H+1))
+			fill := "#ffffff"
+			switch {
+			case red&bit != 0:
+				fill = "#d62828"
+			case p.GetMask()&bit != 0:
+				fill = "#f7c815"
+			}
+			cy := top + (position.H-1-row)*render_cell + render_cell/2
+			fmt.Fprintf(&sb, "<circle cx=\"%d\" cy=\"%d\" r=\"%d\" fill=\"%s\"/>\n", cx, cy, render_cell*2/5, fill)
+		}
+	}
+	sb.WriteString("</svg>\n")
+	return os.WriteFile(path, []byte(sb.String()), 0o644)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// A position of a repertoire. On the repertoire side's turn, `Move` is the recommended column
+// and `Replies` the lines after each opponent reply to it. On the opponent's turn, which only
+// happens at the root, `Move` is empty and `Replies` covers every reply. Moves and columns are
+// written in the notation chosen on the command line.
+type repertoire_line struct {
+	Moves   string              `json:"moves"`
+	Move    string              `json:"move,omitempty"`
+	Score   *int                `json:"score,omitempty"`
+	Replies []*repertoire_reply `json:"replies,omitempty"`
+}
+
+// An opponent reply, with the line that follows it. `Line` is nil where the repertoire ends.
+type repertoire_reply struct {
+	Reply string           `json:"reply"`
+	Line  *repertoire_line `json:"line,omitempty"`
+}
+
+// Prints a repertoire for one side as a JSON tree: one recommended move for every opponent
+// reply up to a depth. Among moves with the best score, the recommendation is the most
+// forgiving one, which leaves the side the most moves that keep the score on its next turn.
+func run_repertoire(args []string) error {
+	flags := new_flag_set("repertoire")
+	side := flags.String("side", "first", "side to build the repertoire for: first or second")
+	depth := flags.Int("depth", 2, "number of opponent replies to cover")
+	weak := flags.Bool("weak", false, "only keep moves which preserve a win, draw or loss")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	var first bool
+	switch *side {
+	case "first":
+		first = true
+	case "second":
+		first = false
+	default:
+		return usage_error(fmt.Errorf("invalid side %q: expected first or second", *side))
+	}
+	if *depth < 1 {
+		return usage_error(fmt.Errorf("invalid depth %d: expected at least 1", *depth))
+	}
+	if is_board_string(flags.Arg(0)) {
+		return usage_error(fmt.Errorf("repertoire expects a sequence of moves, not a board"))
+	}
+
+	root, err := parse_position(flags.Args())
+	if err != nil {
+		return err
+	}
+	builder := repertoire_builder{s: solver.NewSolver(), weak: *weak, notation: *notation}
+	moves := position.Digits.Format(flags.Arg(0))
+
+	var line *repertoire_line
+	if root.IsFirstPlayerToMove() == first {
+		line = builder.our_turn(root, moves, *depth)
+	} else {
+		line = &repertoire_line{Moves: notation.Format(moves), Replies: builder.their_turn(root, moves, *depth)}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(line)
+}
+
+// Builds repertoire lines, sharing one transposition table between them. Moves are passed
+// around in digits and only written in `notation` in the lines built.
+type repertoire_builder struct {
+	s        *solver.Solver
+	weak     bool
+	notation position.Notation
+}
+
+// Recommends a move in a position where the repertoire side is to move, then covers the
+// opponent's replies to it. Returns nil once the game is over.
+func (self *repertoire_builder) our_turn(p *position.Position, moves string, depth int) *repertoire_line {
+	if p.GetMoves() == position.BoardSize {
+		return nil
+	}
+	scores := self.s.Analyze(p, self.weak)
+	best := solver.InvalidMove
+	for _, score := range scores {
+		best = max(best, score)
+	}
+
+	// Ties are broken by forgiveness, then by the centre-first column order
+	col, forgiveness := -1, -1
+	for _, candidate := range position.CentreFirst() {
+		if scores[candidate] != best {
+			continue
+		}
+		if f := self.forgiveness(p, candidate, best); f > forgiveness {
+			col, forgiveness = candidate, f
+		}
+	}
+
+	line := &repertoire_line{Moves: self.notation.Format(moves), Move: self.notation.Column(col), Score: &best}
+	if p.IsWinningMove(col) {
+		return line
+	}
+	next := *p
+	next.Play(col)
+	line.Replies = self.their_turn(&next, moves+string(rune('1'+col)), depth)
+	return line
+}
+
+// Covers every opponent reply in a position where the opponent is to move.
+func (self *repertoire_builder) their_turn(p *position.Position, moves string, depth int) []*repertoire_reply {
+	var replies []*repertoire_reply
+	for col := 0; col < position.W; col++ {
+		if !p.IsPlayable(col) {
+			continue
+		}
+		reply := &repertoire_reply{Reply: self.notation.Column(col)}
+		replies = append(replies, reply)
+		if depth > 1 && !p.IsWinningMove(col) {
+			next := *p
+			next.Play(col)
+			reply.Line = self.our_turn(&next, moves+string(rune('1'+col)), depth-1)
+		}
+	}
+	return replies
+}
+
+// Counts, over every opponent reply to a move, the moves which keep the given score on the
+// following turn. Immediate wins count as a single move.
+func (self *repertoire_builder) forgiveness(p *position.Position, col int, score int) int {
+	if p.IsWinningMove(col) {
+		return 1
+	}
+	next := *p
+	next.Play(col)
+
+	count := 0
+	for reply := 0; reply < position.W; reply++ {
+		if !next.IsPlayable(reply) || next.IsWinningMove(reply) {
+			continue
+		}
+		after := next
+		after.Play(reply)
+		if after.GetMoves() == position.BoardSize {
+			count++
+			continue
+		}
+		for _, s := range self.s.Analyze(&after, self.weak) {
+			if s >= score {
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/YKhan142008/c4-solver/internal/sampler"
+)
+
+// Prints the win, draw and loss rates of random playouts from a position.
+func run_sample(args []string) error {
+	flags := new_flag_set("sample")
+	playouts := flags.Int("playouts", 10000, "number of random games to play")
+	heuristic := flags.Bool("heuristic", false, "take wins, block threats and avoid losing moves during playouts")
+	seed := flags.Int64("seed", 1, "random seed for the playouts")
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	p, err := parse_position(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	policy := sampler.Uniform
+	if *heuristic {
+		policy = sampler.Heuristic
+	}
+	estimate := sampler.NewSampler(*seed, policy).Sample(p, *playouts)
+
+	fmt.Printf("win %.3f draw %.3f loss %.3f (%d playouts)\n",
+		estimate.WinRate(), estimate.DrawRate(), estimate.LossRate(), estimate.Playouts())
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Replays a search log written by `analyze -search-log` as an indented trace of each solve:
+// its position, the windows searched, and the score returned by every root move.
+func run_search_replay(args []string) error {
+	flags := new_flag_set("search-replay")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return usage_error(fmt.Errorf("search-replay expects a single log file"))
+	}
+
+	f, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if err := search_replay_event(strings.Fields(scanner.Text()), *notation); err != nil {
+			return parse_error(fmt.Errorf("line %d: %w", line, err))
+		}
+	}
+	return scanner.Err()
+}
+
+// Prints a single event of a search log, split into its fields, with columns in a notation.
+func search_replay_event(fields []string, notation position.Notation) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	switch event, values := fields[0], fields[1:]; {
+	case event == "S" && len(values) == 3:
+		p, err := position.PositionFromBoardString(values[2])
+		if err != nil {
+			return err
+		}
+		kind := "strong"
+		if values[1] == "true" {
+			kind = "weak"
+		}
+		fmt.Printf("\n%s solve after %s moves, x to play:\n%s", kind, values[0], p)
+	case event == "W" && len(values) == 2:
+		fmt.Printf("  window [%s, %s]\n", values[0], values[1])
+	case event == "O":
+		fmt.Printf("    order %s\n", notation.Format(strings.Join(values, " ")))
+	case event == "M" && len(values) == 2:
+		fmt.Printf("    column %s: %s\n", notation.Format(values[0]), values[1])
+	case event == "C" && len(values) == 2:
+		fmt.Printf("    column %s: %s, cutoff\n", notation.Format(values[0]), values[1])
+	case event == "R" && len(values) == 1:
+		fmt.Printf("  returned %s\n", values[0])
+	case event == "E" && len(values) == 2:
+		fmt.Printf("score %s in %s nodes\n", values[0], values[1])
+	default:
+		return fmt.Errorf("malformed event %q", strings.Join(fields, " "))
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/YKhan142008/c4-solver/internal/motif"
+)
+
+// Prints the board of a position, or a plain text description of it, optionally followed by
+// the motifs found in the position.
+func run_show(args []string) error {
+	flags := new_flag_set("show")
+	describe := flags.Bool("describe", false, "print an accessible text description instead of the board")
+	motifs := flags.Bool("motifs", false, "also list the motifs found in the position")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	p, err := parse_position(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	if *describe {
+		fmt.Println(p.Describe(*notation))
+	} else {
+		fmt.Print(p)
+	}
+
+	if *motifs {
+		for _, finding := range motif.Detect(p) {
+			fmt.Println(finding.Format(*notation))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/YKhan142008/c4-solver/internal/transpose"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Lists the positions of a game archive reached by different move orders, with the number of
+// games reaching each of them.
+func run_transpositions(args []string) error {
+	flags := new_flag_set("transpositions")
+	games_path := flags.String("games", "-", "file with one game per line, or - for standard input")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	var input io.Reader = os.Stdin
+	if *games_path != "-" {
+		f, err := os.Open(*games_path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	// Ignores anything after the moves, such as results or player names
+	var games []string
+	var lines []int
+	line := 0
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		line++
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			games = append(games, fields[0])
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	transpositions, err := transpose.Find(games)
+	if err != nil {
+		if invalid, ok := err.(transpose.InvalidGame); ok {
+			return parse_error(fmt.Errorf("line %d: %w", lines[invalid.Game], invalid.Err))
+		}
+		return err
+	}
+
+	for _, t := range transpositions {
+		orders := make([]string, len(t.Occurrences))
+		for i, occurrence := range t.Occurrences {
+			orders[i] = fmt.Sprintf("%s (line %d)", notation.Format(occurrence.Moves), lines[occurrence.Game])
+		}
+		fmt.Printf("ply %d, %d games: %s\n", t.Plies, t.Games, strings.Join(orders, ", "))
+	}
+	return nil
+}
+
+// Prints the discs added and removed between two positions, e.g. to compare near transpositions.
+func run_diff(args []string) error {
+	flags := new_flag_set("diff")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return usage_error(fmt.Errorf("diff expects two positions"))
+	}
+
+	a, err := parse_position(flags.Args()[:1])
+	if err != nil {
+		return err
+	}
+	b, err := parse_position(flags.Args()[1:])
+	if err != nil {
+		return err
+	}
+
+	diff := transpose.Compare(a, b)
+	if diff.IsEmpty() {
+		fmt.Println("same discs")
+		return nil
+	}
+	for player, name := range []string{"Red", "Yellow"} {
+		if cells := diff.Added[player]; cells != 0 {
+			fmt.Printf("%s added: %s\n", name, position.DescribeCells(cells, *notation))
+		}
+		if cells := diff.Removed[player]; cells != 0 {
+			fmt.Printf("%s removed: %s\n", name, position.DescribeCells(cells, *notation))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// Something which scores positions given as move sequences.
+type verify_engine interface {
+	score(moves string, p *position.Position) (int, error)
+	// Indicates whether only the sign of scores is meaningful.
+	is_weak() bool
+	close() error
+}
+
+// Solves a list of positions with two engine configurations and reports every disagreement.
+//
+// A configuration is either a comma separated list of solver options, e.g. "weak,table=1000003",
+// an empty string for the defaults, or "exec:" followed by the command line of an external
+// engine. External engines receive one move sequence per line on standard input and must
+// answer each with a line whose second field is the score, as in "4453 -2".
+func run_verify(args []string) error {
+	flags := new_flag_set("verify")
+	config_a := flags.String("a", "", "first engine configuration")
+	config_b := flags.String("b", "", "second engine configuration")
+	positions := flags.String("positions", "-", "file with one move sequence per line, or - for standard input")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	a, err := new_verify_engine(*config_a)
+	if err != nil {
+		return err
+	}
+	defer a.close()
+	b, err := new_verify_engine(*config_b)
+	if err != nil {
+		return err
+	}
+	defer b.close()
+
+	var input io.Reader = os.Stdin
+	if *positions != "-" {
+		f, err := os.Open(*positions)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	line, count, disagreements := 0, 0, 0
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		line++
+		// Ignores anything after the moves, such as the expected score of benchmark files
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		moves := fields[0]
+		p, err := position.PositionFromMoves(moves)
+		if err != nil {
+			return parse_error(fmt.Errorf("line %d: %w", line, err))
+		}
+
+		score_a, err := a.score(moves, p)
+		if err != nil {
+			return fmt.Errorf("%s: engine a: %w", notation.Format(moves), err)
+		}
+		score_b, err := b.score(moves, p)
+		if err != nil {
+			return fmt.Errorf("%s: engine b: %w", notation.Format(moves), err)
+		}
+		count++
+
+		if a.is_weak() || b.is_weak() {
+			score_a, score_b = cmp.Compare(score_a, 0), cmp.Compare(score_b, 0)
+		}
+		if score_a != score_b {
+			disagreements++
+			fmt.Printf("%s: a=%d b=%d\n", notation.Format(moves), score_a, score_b)
+			// Reproduces with the moves as read, which external engines may expect
+			fmt.Printf("  reproduce: echo %s | connect4 verify -a %s -b %s\n",
+				shell_quote(moves), shell_quote(*config_a), shell_quote(*config_b))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d positions, %d disagreements\n", count, disagreements)
+	if disagreements > 0 {
+		return check_error(fmt.Errorf("engines disagree on %d positions", disagreements))
+	}
+	return nil
+}
+
+// Quotes an argument for POSIX shells, so reproduce commands can be pasted as they are.
+func shell_quote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+func new_verify_engine(config string) (verify_engine, error) {
+	if command, ok := strings.CutPrefix(config, "exec:"); ok {
+		return new_external_engine(command)
+	}
+
+	engine := &solver_engine{}
+	var options solver.Options
+	for _, option := range strings.Split(config, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "":
+		case "weak":
+			engine.weak = true
+		case "table":
+			size, err := strconv.Atoi(value)
+			if err != nil || size <= 0 {
+				return nil, usage_error(fmt.Errorf("invalid table size %q", value))
+			}
+			options.TableSize = size
+		default:
+			return nil, usage_error(fmt.Errorf("unknown solver option %q", key))
+		}
+	}
+	engine.solver = solver.NewSolverWithOptions(options)
+	return engine, nil
+}
+
+type solver_engine struct {
+	solver *solver.Solver
+	weak   bool
+}
+
+func (self *solver_engine) score(moves string, p *position.Position) (int, error) {
+	return self.solver.Solve(p, self.weak), nil
+}
+
+func (self *solver_engine) is_weak() bool {
+	return self.weak
+}
+
+func (self *solver_engine) close() error {
+	return nil
+}
+
+type external_engine struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+}
+
+func new_external_engine(command string) (*external_engine, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, usage_error(fmt.Errorf("missing external engine command"))
+	}
+
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &external_engine{cmd: cmd, stdin: stdin, stdout: bufio.NewScanner(stdout)}, nil
+}
+
+func (self *external_engine) score(moves string, p *position.Position) (int, error) {
+	if _, err := fmt.Fprintln(self.stdin, moves); err != nil {
+		return 0, err
+	}
+	if !self.stdout.Scan() {
+		if err := self.stdout.Err(); err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("external engine exited")
+	}
+
+	fields := strings.Fields(self.stdout.Text())
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("invalid external engine answer %q", self.stdout.Text())
+	}
+	return strconv.Atoi(fields[1])
+}
+
+func (self *external_engine) is_weak() bool {
+	return false
+}
+
+func (self *external_engine) close() error {
+	self.stdin.Close()
+	return self.cmd.Wait()
+}
@@ -0,0 +1,17 @@
+package main
+
+import "testing"
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct{ arg, want string }{
+		{"", "''"},
+		{"weak,table=1000003", "'weak,table=1000003'"},
+		{"exec:engine $HOME `id`", "'exec:engine $HOME `id`'"},
+		{"exec:sh -c 'echo'", `'exec:sh -c '\''echo'\'''`},
+	}
+	for _, test := range tests {
+		if got := shell_quote(test.arg); got != test.want {
+			t.Errorf("shell_quote(%q) = %s, want %s", test.arg, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// Prints the score of every legal move with the opponent's best reply.
+func run_what_if(args []string) error {
+	flags := new_flag_set("whatif")
+	weak := flags.Bool("weak", false, "only compute whether each move wins, draws or loses")
+	notation := notation_flag(flags)
+	if err := parse_flags(flags, args); err != nil {
+		return err
+	}
+
+	p, err := parse_position(flags.Args())
+	if err != nil {
+		return err
+	}
+	if p.IsWonPosition() {
+		return parse_error(fmt.Errorf("position is already won"))
+	}
+
+	fmt.Printf("%6s  %5s  %5s\n", "column", "score", "reply")
+	for _, result := range solver.NewSolver().WhatIf(p, *weak) {
+		reply := "-"
+		if result.Reply >= 0 {
+			reply = notation.Column(result.Reply)
+		}
+		fmt.Printf("%6s  %+5d  %5s\n", notation.Column(result.Column), result.Score, reply)
+	}
+	return nil
+}
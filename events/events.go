@@ -0,0 +1,169 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Publishes engine activity to embedders, so GUIs and bots can react to it without polling.
+//
+// Producers such as `solver.Solver` and `referee.Game` publish to a `Bus` when one is attached
+// to them. Subscribers receive events either through a callback, called synchronously by the
+// publisher, or through a buffered channel.
+
+type Kind int
+
+const (
+	// A move was played. `Position` is the position after the move and `Column` the move.
+	MovePlayed Kind = iota
+	// A solve started. `Position` is the solved position.
+	SolveStarted
+	// A solve finished. `Score` is the result and `Nodes` the number of nodes explored.
+	SolveFinished
+	// A move lost ground against the best move. `Position` is the position before the move,
+	// `Score` the mover's score after the move, and `Best` their best score before it.
+	BlunderDetected
+)
+
+func (k Kind) String() string {
+	switch k {
+	case MovePlayed:
+		return "move played"
+	case SolveStarted:
+		return "solve started"
+	case SolveFinished:
+		return "solve finished"
+	case BlunderDetected:
+		return "blunder detected"
+	}
+	return "unknown"
+}
+
+// An event published on a `Bus`. Fields which do not apply to the `Kind` are left at their
+// zero value, except `Column` which is -1.
+type Event struct {
+	Kind     Kind
+	Position position.Position
+	// 0-based index of the column played.
+	Column int
+	Weak   bool
+	Score  int
+	Best   int
+	Nodes  uint64
+}
+
+type subscriber struct {
+	id     int
+	kinds  []Kind
+	notify func(Event)
+}
+
+// Indicates whether the subscriber receives events of the given kind. No kinds means all.
+func (self *subscriber) wants(kind Kind) bool {
+	if len(self.kinds) == 0 {
+		return true
+	}
+	for _, k := range self.kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Delivers events to subscribers, in the order they subscribed. A `Bus` is safe for concurrent
+// use.
+type Bus struct {
+	mu          sync.Mutex
+	next_id     int
+	subscribers []*subscriber
+}
+
+// Creates a new `Bus` without subscribers.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Calls `notify` for every event of the given kinds, or of every kind if none are given.
+//
+// Callbacks run on the publisher's goroutine, so slow callbacks slow down the engine. They
+// may publish further events or subscribe, but must not block on the publisher.
+//
+// # Returns
+//
+// A function which cancels the subscription.
+func (self *Bus) Subscribe(notify func(Event), kinds ...Kind) func() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	id := self.next_id
+	self.next_id++
+	self.subscribers = append(self.subscribers, &subscriber{id: id, kinds: kinds, notify: notify})
+	return func() {
+		self.mu.Lock()
+		defer self.mu.Unlock()
+		for i, s := range self.subscribers {
+			if s.id == id {
+				self.subscribers = append(self.subscribers[:i:i], self.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Sends every event of the given kinds, or of every kind if none are given, on a channel.
+//
+// Events are dropped rather than blocking the publisher when the channel's buffer is full.
+//
+// # Arguments
+//
+// * `buffer`: The capacity of the channel.
+//
+// # Returns
+//
+// The channel, and a function which cancels the subscription and closes the channel.
+func (self *Bus) Channel(buffer int, kinds ...Kind) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	var mu sync.Mutex
+	closed := false
+	cancel := self.Subscribe(func(e Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- e:
+		default:
+		}
+	}, kinds...)
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			cancel()
+			mu.Lock()
+			defer mu.Unlock()
+			closed = true
+			close(ch)
+		})
+	}
+}
+
+// Delivers an event to every interested subscriber. A nil `Bus` discards the event.
+func (self *Bus) Publish(e Event) {
+	if self == nil {
+		return
+	}
+	self.mu.Lock()
+	var targets []*subscriber
+	for _, s := range self.subscribers {
+		if s.wants(e.Kind) {
+			targets = append(targets, s)
+		}
+	}
+	self.mu.Unlock()
+
+	for _, s := range targets {
+		s.notify(e)
+	}
+}
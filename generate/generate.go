@@ -0,0 +1,162 @@
+package generate
+
+import (
+	"math/rand"
+
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// Generates random game prefixes and random positions from a seedable source.
+//
+// Games are built by choosing each move uniformly among the playable columns which do not
+// complete a 4-alignment, so generated positions are never already won. A game that runs out
+// of such moves before reaching the requested ply count is discarded and restarted.
+
+// The number of games tried before giving up on a request.
+const MaxAttempts int = 10000
+
+// Reports whether a generated position should be kept.
+type Filter func(p *position.Position) bool
+
+type Generator struct {
+	rng *rand.Rand
+}
+
+// Creates a new `Generator`. The same seed always produces the same sequence of results.
+func NewGenerator(seed int64) *Generator {
+	return &Generator{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Generates a random game prefix.
+//
+// # Arguments
+//
+// * `plies`: The number of moves to play, between 0 and `position.BoardSize`.
+//
+// # Returns
+//
+// The 1-based column digits of the moves played, and the resulting position.
+//
+// # Errors
+//
+// Returns `InvalidPlies` if `plies` is out of range, or `NoMatchingPosition` if no game could
+// be completed within `MaxAttempts` attempts.
+func (self *Generator) RandomGame(plies int) (string, *position.Position, error) {
+	return self.RandomPosition(plies)
+}
+
+// Generates a random game prefix whose position passes every filter.
+//
+// # Arguments
+//
+// * `plies`: The number of moves to play, between 0 and `position.BoardSize`.
+// * `filters`: Conditions the final position must meet.
+//
+// # Returns
+//
+// The 1-based column digits of the moves played, and the resulting position.
+//
+// # Errors
+//
+// Returns `InvalidPlies` if `plies` is out of range, or `NoMatchingPosition` if no matching
+// position was found within `MaxAttempts` attempts.
+func (self *Generator) RandomPosition(plies int, filters ...Filter) (string, *position.Position, error) {
+	if plies < 0 || plies > position.BoardSize {
+		return "", nil, InvalidPlies{Plies: plies}
+	}
+
+	for attempt := 0; attempt < MaxAttempts; attempt++ {
+		moves, p, ok := self.try_game(plies)
+		if ok && matches(p, filters) {
+			return moves, p, nil
+		}
+	}
+	return "", nil, NoMatchingPosition{Plies: plies, Attempts: MaxAttempts}
+}
+
+func (self *Generator) try_game(plies int) (string, *position.Position, bool) {
+	p := position.NewPosition()
+	moves := make([]byte, 0, plies)
+	var candidates [position.W]int
+
+	for p.GetMoves() < plies {
+		n := 0
+		for col := 0; col < position.W; col++ {
+			if p.IsPlayable(col) && !p.IsWinningMove(col) {
+				candidates[n] = col
+				n++
+			}
+		}
+		if n == 0 {
+			return "", nil, false
+		}
+
+		col := candidates[self.rng.Intn(n)]
+		p.Play(col)
+		moves = append(moves, byte('1'+col))
+	}
+	return string(moves), p, true
+}
+
+func matches(p *position.Position, filters []Filter) bool {
+	for _, filter := range filters {
+		if !filter(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Keeps positions where the game is not over, i.e. the board is not full.
+func NonTerminal(p *position.Position) bool {
+	return p.GetMoves() < position.BoardSize && !p.IsWonPosition()
+}
+
+// Keeps positions where the current player cannot win with their next move.
+func NoImmediateWin(p *position.Position) bool {
+	return !p.CanWinNext()
+}
+
+// Keeps positions whose exact score lies within [-`max_score`, `max_score`].
+//
+// Solving is expensive for early positions, so this filter is best used with at least 16 plies
+// or combined after cheaper filters.
+//
+// # Arguments
+//
+// * `s`: The solver used to score positions.
+// * `max_score`: The largest accepted absolute score. 0 keeps only drawn positions.
+func Balanced(s *solver.Solver, max_score int) Filter {
+	return func(p *position.Position) bool {
+		if !NonTerminal(p) {
+			return false
+		}
+		score := s.Solve(p, max_score == 0)
+		return score >= -max_score && score <= max_score
+	}
+}
+
+// Keeps positions where exactly one move wins for the current player, so the position makes
+// a puzzle with a single solution. That move must be neither an immediate win nor a forced
+// block of an opponent threat.
+//
+// Like `Balanced`, this filter solves positions and is best used with at least 16 plies.
+//
+// # Arguments
+//
+// * `s`: The solver used to score moves.
+func UniqueWin(s *solver.Solver) Filter {
+	return func(p *position.Position) bool {
+		if !NonTerminal(p) || !NoImmediateWin(p) || p.OpponentThreats()&p.Possible() != 0 {
+			return false
+		}
+		wins := 0
+		for _, score := range s.Analyze(p, true) {
+			if score > 0 {
+				wins++
+			}
+		}
+		return wins == 1
+	}
+}
@@ -0,0 +1,24 @@
+package generate
+
+import (
+	"fmt"
+
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+type InvalidPlies struct {
+	Plies int
+}
+
+type NoMatchingPosition struct {
+	Plies    int
+	Attempts int
+}
+
+func (e InvalidPlies) Error() string {
+	return fmt.Sprintf("invalid ply count %d: expected between 0 and %d", e.Plies, position.BoardSize)
+}
+
+func (e NoMatchingPosition) Error() string {
+	return fmt.Sprintf("no matching position with %d plies found in %d attempts", e.Plies, e.Attempts)
+}
@@ -0,0 +1,50 @@
+package generate_test
+
+import (
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/generate"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+func TestRandomPositionIsReproducible(t *testing.T) {
+	a, _, err := generate.NewGenerator(7).RandomGame(20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _, _ := generate.NewGenerator(7).RandomGame(20)
+	if a != b || len(a) != 20 {
+		t.Errorf("RandomGame(20) = %q then %q, want the same 20 moves", a, b)
+	}
+}
+
+func TestUniqueWin(t *testing.T) {
+	unique_win := generate.UniqueWin(solver.NewSolver())
+
+	// The only winning move blocks the opponent's single threat
+	if unique_win(c4test.Moves(t, "4114223543234441571776")) {
+		t.Error("UniqueWin accepted a forced block")
+	}
+
+	s := solver.NewSolver()
+	g := generate.NewGenerator(1)
+	for i := 0; i < 5; i++ {
+		moves, p, err := g.RandomPosition(22, unique_win)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if p.CanWinNext() || p.OpponentThreats()&p.Possible() != 0 {
+			t.Errorf("%s: the solution is an immediate win or a forced block", moves)
+		}
+		wins := 0
+		for _, score := range s.Analyze(p, true) {
+			if score > 0 {
+				wins++
+			}
+		}
+		if wins != 1 {
+			t.Errorf("%s: %d winning moves, want 1", moves, wins)
+		}
+	}
+}
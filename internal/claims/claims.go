@@ -0,0 +1,68 @@
+package claims
+
+import (
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// When an engine should resign, offer a draw or accept one, based on proven results.
+//
+// Decisions are only taken on exact solver results, so an engine never resigns a position it
+// could still save, nor offers a draw in a position it could still win.
+
+type Policy struct {
+	// Resigns once a loss in at most this many moves is proven, counting both players' moves.
+	// 0 never resigns.
+	ResignWithin int
+	// Offers draws in proven drawn positions, and accepts draws in positions which are not
+	// proven wins.
+	ClaimDraws bool
+}
+
+type Decision int
+
+const (
+	// Keeps playing.
+	Continue Decision = iota
+	OfferDraw
+	Resign
+)
+
+func (d Decision) String() string {
+	switch d {
+	case OfferDraw:
+		return "offer draw"
+	case Resign:
+		return "resign"
+	}
+	return "continue"
+}
+
+// Decides whether the player to move should resign or offer a draw instead of playing.
+//
+// # Arguments
+//
+// * `s`: The solver proving results.
+// * `p`: A position which is not already won, with the engine to move.
+func (self Policy) Decide(s *solver.Solver, p *position.Position) Decision {
+	if p.CanWinNext() || (self.ResignWithin <= 0 && !self.ClaimDraws) {
+		return Continue
+	}
+
+	score := s.Solve(p, false)
+	switch {
+	case score < 0 && self.ResignWithin > 0 && solver.PliesToEnd(p.GetMoves(), score) <= self.ResignWithin:
+		return Resign
+	case score == 0 && self.ClaimDraws:
+		return OfferDraw
+	}
+	return Continue
+}
+
+// Decides whether the player to move should accept a draw offered by the opponent.
+func (self Policy) AcceptsDraw(s *solver.Solver, p *position.Position) bool {
+	if !self.ClaimDraws || p.CanWinNext() {
+		return false
+	}
+	return s.Solve(p, true) <= 0
+}
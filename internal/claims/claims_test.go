@@ -0,0 +1,62 @@
+package claims_test
+
+import (
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/internal/claims"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+const (
+	// The player to move faces a double threat and loses 2 plies later.
+	lost_in_2 = "44553"
+	// The player to move loses on the 6th ply from here, a move of the opponent.
+	lost_in_6 = "455173517423741771373363214"
+	// A proven draw.
+	drawn = "67641663525557523326775473424144"
+	// The player to move wins by creating a double threat.
+	won = "4455"
+)
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		moves  string
+		policy claims.Policy
+		want   claims.Decision
+	}{
+		{lost_in_2, claims.Policy{ResignWithin: 2}, claims.Resign},
+		{lost_in_2, claims.Policy{ResignWithin: 1}, claims.Continue},
+		{lost_in_6, claims.Policy{ResignWithin: 6}, claims.Resign},
+		{lost_in_6, claims.Policy{ResignWithin: 5}, claims.Continue},
+		{lost_in_6, claims.Policy{}, claims.Continue},
+		{drawn, claims.Policy{ClaimDraws: true}, claims.OfferDraw},
+		{drawn, claims.Policy{ResignWithin: 42}, claims.Continue},
+		{won, claims.Policy{ResignWithin: 42, ClaimDraws: true}, claims.Continue},
+	}
+	s := solver.NewSolver()
+	for _, test := range tests {
+		if got := test.policy.Decide(s, c4test.Moves(t, test.moves)); got != test.want {
+			t.Errorf("%+v.Decide(%s) = %v, want %v", test.policy, test.moves, got, test.want)
+		}
+	}
+}
+
+func TestAcceptsDraw(t *testing.T) {
+	tests := []struct {
+		moves  string
+		policy claims.Policy
+		want   bool
+	}{
+		{drawn, claims.Policy{ClaimDraws: true}, true},
+		{lost_in_6, claims.Policy{ClaimDraws: true}, true},
+		{won, claims.Policy{ClaimDraws: true}, false},
+		{drawn, claims.Policy{}, false},
+	}
+	s := solver.NewSolver()
+	for _, test := range tests {
+		if got := test.policy.AcceptsDraw(s, c4test.Moves(t, test.moves)); got != test.want {
+			t.Errorf("%+v.AcceptsDraw(%s) = %t, want %t", test.policy, test.moves, got, test.want)
+		}
+	}
+}
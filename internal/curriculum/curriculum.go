@@ -0,0 +1,162 @@
+package curriculum
+
+import (
+	"sort"
+
+	"github.com/YKhan142008/c4-solver/generate"
+	"github.com/YKhan142008/c4-solver/internal/motif"
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// Mines puzzles illustrating tactical motifs and groups them into graded lessons.
+//
+// A puzzle is a position with a single winning move, as kept by `generate.UniqueWin`, whose
+// winning move creates the motif of its lesson for the player to move. Lessons go from the
+// most direct motif to the most positional one, and the puzzles of a lesson from the shortest
+// win to the longest.
+
+// A motif taught by a lesson.
+type Topic int
+
+const (
+	// The winning move creates two playable threats at once.
+	DoubleThreat Topic = iota
+	// The winning move completes a seven, creating stacked threats.
+	Seven
+	// The winning move creates a threat on a row of the mover's parity, odd for the first player
+	// and even for the second, which zugzwang eventually forces the opponent to allow.
+	ParitySqueeze
+)
+
+// Every topic, in the order they are taught.
+var Topics = []Topic{DoubleThreat, Seven, ParitySqueeze}
+
+func (t Topic) String() string {
+	switch t {
+	case DoubleThreat:
+		return "double threat"
+	case Seven:
+		return "seven trap"
+	case ParitySqueeze:
+		return "parity squeeze"
+	}
+	return "unknown topic"
+}
+
+type Puzzle struct {
+	// The 1-based column digits of the moves reaching the puzzle.
+	Moves string
+	// 0-based index of the winning column.
+	Solution int
+	// The number of moves until the win with perfect play, counting the winning move.
+	Plies int
+}
+
+type Lesson struct {
+	Topic   Topic
+	Puzzles []Puzzle
+}
+
+// The number of moves played before the puzzles, cycled through while mining. Earlier
+// positions take much longer to solve.
+var puzzle_plies = []int{18, 20, 22, 24, 26, 28}
+
+// Mines a curriculum with up to `count` puzzles per lesson.
+//
+// # Arguments
+//
+// * `s`: The solver used to find and grade the puzzles.
+// * `seed`: The random seed. The same seed always produces the same curriculum.
+// * `count`: The number of puzzles wanted per lesson.
+// * `attempts`: The number of puzzles examined before giving up. Some motifs, such as the
+// seven, are rare, so their lessons may end with fewer puzzles.
+//
+// # Returns
+//
+// One lesson per topic, in the order of `Topics`.
+func Build(s *solver.Solver, seed int64, count int, attempts int) []Lesson {
+	lessons := make([]Lesson, len(Topics))
+	for i, topic := range Topics {
+		lessons[i].Topic = topic
+	}
+
+	g := generate.NewGenerator(seed)
+	unique_win := generate.UniqueWin(s)
+	seen := make(map[uint64]bool)
+	for attempt := 0; attempt < attempts && !complete(lessons, count); attempt++ {
+		moves, p, err := g.RandomPosition(puzzle_plies[attempt%len(puzzle_plies)], unique_win)
+		if err != nil || seen[p.GetKey()] {
+			continue
+		}
+		seen[p.GetKey()] = true
+
+		scores := s.Analyze(p, false)
+		solution := 0
+		for col, score := range scores {
+			if score > scores[solution] {
+				solution = col
+			}
+		}
+		puzzle := Puzzle{Moves: moves, Solution: solution, Plies: solver.PliesToEnd(p.GetMoves(), scores[solution])}
+
+		// Files the puzzle under the first topic it illustrates which still needs puzzles
+		for i := range lessons {
+			if len(lessons[i].Puzzles) < count && Illustrates(p, solution, lessons[i].Topic) {
+				lessons[i].Puzzles = append(lessons[i].Puzzles, puzzle)
+				break
+			}
+		}
+	}
+
+	for i := range lessons {
+		sort.SliceStable(lessons[i].Puzzles, func(a, b int) bool {
+			return lessons[i].Puzzles[a].Plies < lessons[i].Puzzles[b].Plies
+		})
+	}
+	return lessons
+}
+
+func complete(lessons []Lesson, count int) bool {
+	for _, lesson := range lessons {
+		if len(lesson.Puzzles) < count {
+			return false
+		}
+	}
+	return true
+}
+
+// Indicates whether playing a column creates the motif of a topic for the player to move.
+//
+// # Arguments
+//
+// * `p`: A position which is not already won.
+// * `col`: 0-based index of a playable column which does not win immediately.
+// * `topic`: The topic to check.
+func Illustrates(p *position.Position, col int, topic Topic) bool {
+	wanted := motif.DoubleThreat
+	switch topic {
+	case Seven:
+		wanted = motif.Seven
+	case ParitySqueeze:
+		wanted = motif.OddThreat
+		if !p.IsFirstPlayerToMove() {
+			wanted = motif.EvenThreat
+		}
+	}
+
+	// The player to move becomes the opponent once the move is played
+	child := *p
+	child.Play(col)
+	return count(motif.Detect(&child), motif.Opponent, wanted) > count(motif.Detect(p), motif.CurrentPlayer, wanted)
+}
+
+func count(findings []motif.Finding, side motif.Side, m motif.Motif) int {
+	n := 0
+	for _, finding := range findings {
+		if finding.Side == side && finding.Motif == m {
+			n++
+		}
+	}
+	return n
+}
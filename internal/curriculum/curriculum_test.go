@@ -0,0 +1,40 @@
+package curriculum_test
+
+import (
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/internal/curriculum"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+func TestIllustrates(t *testing.T) {
+	p := c4test.Moves(t, "731654422735716424")
+	if !curriculum.Illustrates(p, 1, curriculum.DoubleThreat) {
+		t.Error("column 2 does not illustrate a double threat")
+	}
+	if curriculum.Illustrates(p, 0, curriculum.DoubleThreat) {
+		t.Error("column 1 illustrates a double threat")
+	}
+}
+
+func TestBuild(t *testing.T) {
+	lessons := curriculum.Build(solver.NewSolver(), 1, 2, 300)
+	if len(lessons) != len(curriculum.Topics) {
+		t.Fatalf("Build() returned %d lessons, want %d", len(lessons), len(curriculum.Topics))
+	}
+	for i, lesson := range lessons {
+		if lesson.Topic != curriculum.Topics[i] || len(lesson.Puzzles) == 0 {
+			t.Errorf("lesson %d: %s with %d puzzles", i+1, lesson.Topic, len(lesson.Puzzles))
+		}
+		for j, puzzle := range lesson.Puzzles {
+			p := c4test.Moves(t, puzzle.Moves)
+			if !curriculum.Illustrates(p, puzzle.Solution, lesson.Topic) {
+				t.Errorf("%s: column %d does not illustrate a %s", puzzle.Moves, puzzle.Solution+1, lesson.Topic)
+			}
+			if j > 0 && lesson.Puzzles[j-1].Plies > puzzle.Plies {
+				t.Errorf("%s: puzzles are not graded by length", lesson.Topic)
+			}
+		}
+	}
+}
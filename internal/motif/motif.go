@@ -0,0 +1,207 @@
+package motif
+
+import (
+	"fmt"
+
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Recognizes standard Connect Four patterns in a position.
+//
+// Rows are counted from 1 at the bottom. Since the first player plays the odd numbered discs,
+// zugzwang at the end of a game favours the first player's threats on odd rows and the second
+// player's threats on even rows, which is why threats are classified by row parity.
+
+type Motif int
+
+const (
+	// A threat on an odd row.
+	OddThreat Motif = iota
+	// A threat on an even row.
+	EvenThreat
+	// Two or more threats which can be played immediately. Only one of them can be blocked.
+	DoubleThreat
+	// Two threats directly above one another. Blocking the lower one allows the upper one.
+	StackedThreats
+	// Five discs shaped like a 7, or its mirror, creating stacked threats beside the bar.
+	Seven
+	// A threat directly above an opponent's threat, which the opponent can always block by
+	// waiting for the lower cell to be filled.
+	UselessThreat
+	// Two even threats of the same player in different columns.
+	EvenThreatPair
+)
+
+func (m Motif) String() string {
+	switch m {
+	case OddThreat:
+		return "odd threat"
+	case EvenThreat:
+		return "even threat"
+	case DoubleThreat:
+		return "double threat"
+	case StackedThreats:
+		return "stacked threats"
+	case Seven:
+		return "seven"
+	case UselessThreat:
+		return "useless threat"
+	case EvenThreatPair:
+		return "even threat pair"
+	}
+	return "unknown motif"
+}
+
+type Side int
+
+const (
+	CurrentPlayer Side = iota
+	Opponent
+)
+
+func (s Side) String() string {
+	if s == Opponent {
+		return "opponent"
+	}
+	return "current player"
+}
+
+// A motif found in a position.
+type Finding struct {
+	Motif Motif
+	// The player who benefits from the motif.
+	Side Side
+	// The cells making up the motif: threat cells, or the discs of a seven.
+	Cells uint64
+}
+
+func (f Finding) String() string {
+	return f.Format(position.Digits)
+}
+
+// Describes the finding with its cells in a notation, e.g. "even threat for the current player
+// at column b row 4" in letters.
+func (f Finding) Format(notation position.Notation) string {
+	return fmt.Sprintf("%s for the %s at %s", f.Motif, f.Side, position.DescribeCells(f.Cells, notation))
+}
+
+// Finds every motif for both players of a position.
+//
+// # Returns
+//
+// The findings of the current player followed by those of the opponent, each ordered by motif
+// and then by cell.
+func Detect(p *position.Position) []Finding {
+	var findings []Finding
+	sides := []struct {
+		side            Side
+		discs           uint64
+		threats         uint64
+		opponent_threat uint64
+	}{
+		{CurrentPlayer, p.GetBoard(), p.Threats(), p.OpponentThreats()},
+		{Opponent, p.GetBoard() ^ p.GetMask(), p.OpponentThreats(), p.Threats()},
+	}
+
+	for _, s := range sides {
+		add := func(motif Motif, cells uint64) {
+			findings = append(findings, Finding{Motif: motif, Side: s.side, Cells: cells})
+		}
+
+		for _, cell := range single_bits(s.threats & odd_rows) {
+			add(OddThreat, cell)
+		}
+		for _, cell := range single_bits(s.threats &^ odd_rows) {
+			add(EvenThreat, cell)
+		}
+		if playable := s.threats & p.Possible(); playable&(playable-1) != 0 {
+			add(DoubleThreat, playable)
+		}
+		for _, cell := range single_bits(s.threats & (s.threats >> 1)) {
+			add(StackedThreats, cell|cell<<1)
+		}
+		for _, seven := range sevens {
+			if s.discs&seven.discs == seven.discs && p.GetMask()&seven.threats == 0 {
+				add(Seven, seven.discs)
+			}
+		}
+		for _, cell := range single_bits(s.threats & (s.opponent_threat << 1)) {
+			add(UselessThreat, cell)
+		}
+		even := single_bits(s.threats &^ odd_rows)
+		for i, a := range even {
+			for _, b := range even[i+1:] {
+				if !same_column(a, b) {
+					add(EvenThreatPair, a|b)
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// A mask of the cells on odd rows, counted from 1.
+var odd_rows uint64 = compute_odd_rows()
+
+func compute_odd_rows() uint64 {
+	var mask uint64 = 0
+	for col := 0; col < position.W; col++ {
+		for row := 0; row < position.H; row += 2 {
+			mask |= cell(col, row)
+		}
+	}
+	return mask
+}
+
+// The discs of every seven on the board, with the stacked cells it threatens.
+var sevens []struct{ discs, threats uint64 } = compute_sevens()
+
+func compute_sevens() []struct{ discs, threats uint64 } {
+	var result []struct{ discs, threats uint64 }
+	for col := 0; col+2 < position.W; col++ {
+		for row := 2; row < position.H; row++ {
+			bar := cell(col, row) | cell(col+1, row) | cell(col+2, row)
+
+			// A 7 with its stem going down to the left threatens the column right of the bar
+			if col+3 < position.W && row+1 < position.H {
+				result = append(result, struct{ discs, threats uint64 }{
+					bar | cell(col+1, row-1) | cell(col, row-2),
+					cell(col+3, row) | cell(col+3, row+1),
+				})
+			}
+			// Its mirror threatens the column left of the bar
+			if col > 0 && row+1 < position.H {
+				result = append(result, struct{ discs, threats uint64 }{
+					bar | cell(col+1, row-1) | cell(col+2, row-2),
+					cell(col-1, row) | cell(col-1, row+1),
+				})
+			}
+		}
+	}
+	return result
+}
+
+// Indicates whether two single cells are in the same column.
+func same_column(a uint64, b uint64) bool {
+	for col := 0; col < position.W; col++ {
+		if a&position.ColumnMask(col) != 0 {
+			return b&position.ColumnMask(col) != 0
+		}
+	}
+	return false
+}
+
+func cell(col int, row int) uint64 {
+	return uint64(1) << (row + col*(position.H+1))
+}
+
+// Splits a mask into masks of its individual bits, from the lowest bit.
+func single_bits(mask uint64) []uint64 {
+	var result []uint64
+	for mask != 0 {
+		bit := mask & -mask
+		result = append(result, bit)
+		mask ^= bit
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package motif_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/internal/motif"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Builds a mask from cell names such as "d3", with columns from 'a' and rows from 1.
+func cells(names ...string) uint64 {
+	var mask uint64
+	for _, name := range names {
+		col, row := int(name[0]-'a'), int(name[1]-'1')
+		mask |= uint64(1) << (row + col*(position.H+1))
+	}
+	return mask
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name  string
+		grid  []string
+		motif motif.Motif
+		want  []motif.Finding
+	}{
+		{"odd threat",
+			[]string{".......", ".......", ".......", ".......", "ooo....", "xxx...."},
+			motif.OddThreat,
+			[]motif.Finding{{Motif: motif.OddThreat, Side: motif.CurrentPlayer, Cells: cells("d1")}}},
+		{"even threat",
+			[]string{".......", ".......", ".......", ".......", "ooo....", "xxx...."},
+			motif.EvenThreat,
+			[]motif.Finding{{Motif: motif.EvenThreat, Side: motif.Opponent, Cells: cells("d2")}}},
+		{"useless threat above an opponent threat",
+			[]string{".......", ".......", ".......", ".......", "ooo....", "xxx...."},
+			motif.UselessThreat,
+			[]motif.Finding{{Motif: motif.UselessThreat, Side: motif.Opponent, Cells: cells("d2")}}},
+		{"double threat",
+			[]string{".......", ".......", ".......", ".......", ".......", "..xxx.."},
+			motif.DoubleThreat,
+			[]motif.Finding{{Motif: motif.DoubleThreat, Side: motif.CurrentPlayer, Cells: cells("b1", "f1")}}},
+		{"stacked threats",
+			[]string{".......", ".......", ".......", "......o", "xxx...o", "xxx...o"},
+			motif.StackedThreats,
+			[]motif.Finding{{Motif: motif.StackedThreats, Side: motif.CurrentPlayer, Cells: cells("d1", "d2")}}},
+		{"seven with its stem down to the left",
+			[]string{".......", ".......", ".......", "xxx....", "oxo....", "xoo...."},
+			motif.Seven,
+			[]motif.Finding{{Motif: motif.Seven, Side: motif.CurrentPlayer, Cells: cells("a3", "b3", "c3", "b2", "a1")}}},
+		{"seven with its stem down to the right",
+			[]string{".......", ".......", ".......", "....ooo", "....xox", "....xxo"},
+			motif.Seven,
+			[]motif.Finding{{Motif: motif.Seven, Side: motif.Opponent, Cells: cells("e3", "f3", "g3", "f2", "g1")}}},
+		{"seven whose threats are filled",
+			[]string{".......", ".......", ".......", "xxxo...", "oxoo...", "xooo..."},
+			motif.Seven,
+			nil},
+		{"even threat pair",
+			[]string{".......", ".......", ".......", ".......", ".xxx...", ".ooo..."},
+			motif.EvenThreatPair,
+			[]motif.Finding{{Motif: motif.EvenThreatPair, Side: motif.CurrentPlayer, Cells: cells("a2", "e2")}}},
+		{"even threats in one column are not a pair",
+			[]string{".......", ".......", "xxx....", "ooo....", "xxx....", "ooo...."},
+			motif.EvenThreatPair,
+			nil},
+	}
+	for _, test := range tests {
+		var got []motif.Finding
+		for _, finding := range motif.Detect(c4test.Grid(t, test.grid...)) {
+			if finding.Motif == test.motif {
+				got = append(got, finding)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Detect() found %v, want %v", test.name, got, test.want)
+		}
+	}
+}
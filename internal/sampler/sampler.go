@@ -0,0 +1,139 @@
+package sampler
+
+import (
+	"math/bits"
+	"math/rand"
+
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Estimates the outcome of a position by playing random games to the end.
+//
+// Sampling is far cheaper than solving early positions, and gives a preview of how
+// favourable a position is while an exact solve is still running.
+
+type PlayoutPolicy int
+
+const (
+	// Plays uniformly among all playable columns.
+	Uniform PlayoutPolicy = iota
+	// Always takes an immediate win, blocks the opponent's immediate wins, avoids playing
+	// below the opponent's threats, and plays uniformly among the remaining moves.
+	Heuristic
+)
+
+// The outcome counts of a batch of playouts, from the point of view of the current player.
+type Estimate struct {
+	Wins   int
+	Draws  int
+	Losses int
+}
+
+func (e Estimate) Playouts() int {
+	return e.Wins + e.Draws + e.Losses
+}
+
+func (e Estimate) WinRate() float64 {
+	return rate(e.Wins, e.Playouts())
+}
+
+func (e Estimate) DrawRate() float64 {
+	return rate(e.Draws, e.Playouts())
+}
+
+func (e Estimate) LossRate() float64 {
+	return rate(e.Losses, e.Playouts())
+}
+
+func rate(count int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
+
+type Sampler struct {
+	rng    *rand.Rand
+	policy PlayoutPolicy
+}
+
+// Creates a new `Sampler`. The same seed always produces the same estimates.
+func NewSampler(seed int64, policy PlayoutPolicy) *Sampler {
+	return &Sampler{rng: rand.New(rand.NewSource(seed)), policy: policy}
+}
+
+// Plays random games from a position and counts their outcomes.
+//
+// # Arguments
+//
+// * `p`: The position to sample. It is not modified.
+// * `playouts`: The number of games to play.
+func (self *Sampler) Sample(p *position.Position, playouts int) Estimate {
+	var estimate Estimate
+	for i := 0; i < playouts; i++ {
+		switch self.playout(*p) {
+		case 1:
+			estimate.Wins++
+		case 0:
+			estimate.Draws++
+		default:
+			estimate.Losses++
+		}
+	}
+	return estimate
+}
+
+// Plays a single game to the end.
+//
+// # Returns
+//
+// 1 if the player to move in the initial position wins, -1 if they lose, or 0 for a draw.
+func (self *Sampler) playout(p position.Position) int {
+	if p.IsWonPosition() {
+		// The previous player completed a 4-alignment
+		return -1
+	}
+
+	sign := 1
+	for p.GetMoves() < position.BoardSize {
+		if self.policy == Heuristic {
+			if p.CanWinNext() {
+				return sign
+			}
+			moves := p.PossibleNonLosingMoves()
+			if moves == 0 {
+				// The opponent wins on their next move whatever is played
+				return -sign
+			}
+			p.PlayMove(self.pick_bit(moves))
+		} else {
+			col := self.pick_column(&p)
+			if p.IsWinningMove(col) {
+				return sign
+			}
+			p.Play(col)
+		}
+		sign = -sign
+	}
+	return 0
+}
+
+func (self *Sampler) pick_column(p *position.Position) int {
+	var candidates [position.W]int
+	n := 0
+	for col := 0; col < position.W; col++ {
+		if p.IsPlayable(col) {
+			candidates[n] = col
+			n++
+		}
+	}
+	return candidates[self.rng.Intn(n)]
+}
+
+// Returns one of the set bits of a mask, chosen uniformly.
+func (self *Sampler) pick_bit(mask uint64) uint64 {
+	for i := self.rng.Intn(bits.OnesCount64(mask)); i > 0; i-- {
+		mask &= mask - 1
+	}
+	return mask & -mask
+}
@@ -0,0 +1,131 @@
+package teach
+
+import (
+	"fmt"
+
+	"github.com/YKhan142008/c4-solver/internal/motif"
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+// Progressive hints for the player to move, from a vague pointer to the exact move.
+//
+// Hints are derived from the best move found by the solver and from the motifs that move
+// creates, so a learner can be nudged towards the idea before being told the answer.
+
+// The number of hints returned by `Hints()`.
+const Levels int = 3
+
+// Computes increasingly precise hints towards the best move of a position.
+//
+// # Arguments
+//
+// * `s`: The solver used to find the best move.
+// * `p`: A position which is not already won.
+// * `notation`: The notation of the columns named in the hints.
+//
+// # Returns
+//
+// `Levels` hints: the idea behind the best move and the area of the board to look at, then
+// the column range or the motif the move creates, and finally the move itself.
+//
+// # Errors
+//
+// Returns `FullBoard` if no column is playable.
+func Hints(s *solver.Solver, p *position.Position, notation position.Notation) ([]string, error) {
+	if p.GetMoves() == position.BoardSize {
+		return nil, FullBoard{}
+	}
+
+	scores := s.Analyze(p, false)
+	best := -1
+	// Prefers central columns among equally good moves
+	for _, col := range position.CentreFirst() {
+		if scores[col] != solver.InvalidMove && (best < 0 || scores[col] > scores[best]) {
+			best = col
+		}
+	}
+
+	idea, detail := explain(p, best, scores[best], notation)
+	return []string{
+		fmt.Sprintf("%s %s.", idea, region(best)),
+		detail,
+		fmt.Sprintf("Play column %s.", notation.Column(best)),
+	}, nil
+}
+
+// Returns a vague idea behind a move and a more precise detail about it.
+func explain(p *position.Position, col int, score int, notation position.Notation) (string, string) {
+	if p.IsWinningMove(col) {
+		return "You can win right now: look", "One of your threats can be played immediately."
+	}
+
+	forced := p.OpponentThreats() & p.Possible()
+	if forced&(forced-1) != 0 {
+		return "Your opponent has more than one way to win and you can only block one: look",
+			fmt.Sprintf("Every move loses, the most stubborn move is in %s.", columns(col, notation))
+	}
+	if forced != 0 {
+		return "Your opponent threatens to win: look", "You must block the cell where your opponent would connect four."
+	}
+
+	// Motifs for the player to move, who is the opponent once the move is played
+	child := *p
+	child.Play(col)
+	before := count_motifs(motif.Detect(p), motif.CurrentPlayer)
+	after := count_motifs(motif.Detect(&child), motif.Opponent)
+	for _, m := range []motif.Motif{motif.DoubleThreat, motif.StackedThreats, motif.Seven, motif.OddThreat, motif.EvenThreat} {
+		if after[m] > before[m] {
+			return fmt.Sprintf("Look for a move creating %s", with_article(m)),
+				fmt.Sprintf("The best move creates %s in %s.", with_article(m), columns(col, notation))
+		}
+	}
+
+	switch {
+	case score > 0:
+		return "You have a winning move: look", fmt.Sprintf("The winning move is in %s.", columns(col, notation))
+	case score == 0:
+		return "The best you can get is a draw: look", fmt.Sprintf("The drawing move is in %s.", columns(col, notation))
+	}
+	return "Every move loses against perfect play, so resist as long as possible: look",
+		fmt.Sprintf("The most stubborn move is in %s.", columns(col, notation))
+}
+
+func count_motifs(findings []motif.Finding, side motif.Side) map[motif.Motif]int {
+	counts := make(map[motif.Motif]int)
+	for _, finding := range findings {
+		if finding.Side == side {
+			counts[finding.Motif]++
+		}
+	}
+	return counts
+}
+
+func with_article(m motif.Motif) string {
+	switch m {
+	case motif.StackedThreats:
+		return m.String()
+	case motif.OddThreat, motif.EvenThreat, motif.EvenThreatPair:
+		return "an " + m.String()
+	}
+	return "a " + m.String()
+}
+
+// Names the third of the board containing a column.
+func region(col int) string {
+	switch {
+	case col < position.W/2-1:
+		return "on the left"
+	case col > position.W/2+1:
+		return "on the right"
+	}
+	return "in the centre"
+}
+
+// Names a small range of columns containing a column, without giving it away.
+func columns(col int, notation position.Notation) string {
+	first := max(col-1, 0)
+	last := min(first+2, position.W-1)
+	first = last - 2
+	return fmt.Sprintf("columns %s to %s", notation.Column(first), notation.Column(last))
+}
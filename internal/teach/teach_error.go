@@ -0,0 +1,7 @@
+package teach
+
+type FullBoard struct{}
+
+func (e FullBoard) Error() string {
+	return "no hints: every column is full"
+}
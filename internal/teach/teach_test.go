@@ -0,0 +1,45 @@
+package teach_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/internal/teach"
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+func TestHintsEndWithTheBestMove(t *testing.T) {
+	p := c4test.Moves(t, "4114223543234441571776")
+	for _, test := range []struct {
+		notation position.Notation
+		want     string
+	}{
+		{position.Digits, "Play column 2."},
+		{position.Letters, "Play column b."},
+	} {
+		hints, err := teach.Hints(solver.NewSolver(), p, test.notation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hints) != teach.Levels || hints[teach.Levels-1] != test.want {
+			t.Errorf("Hints(%v) = %q, want %d hints ending with %q", test.notation, hints, teach.Levels, test.want)
+		}
+	}
+}
+
+func TestHintsOnFullBoard(t *testing.T) {
+	p := c4test.Grid(t,
+		"ooxooxx",
+		"oooxxxo",
+		"xxoxoxx",
+		"oooxoox",
+		"xxxooox",
+		"xxxoxoo",
+	)
+	_, err := teach.Hints(solver.NewSolver(), p, position.Digits)
+	if !errors.Is(err, teach.FullBoard{}) {
+		t.Errorf("Hints() error = %v, want FullBoard", err)
+	}
+}
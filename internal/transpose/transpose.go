@@ -0,0 +1,131 @@
+package transpose
+
+import (
+	"sort"
+
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/referee"
+)
+
+// Detects positions reached by different move orders, within and across games.
+//
+// Positions are compared by their canonical key, so a position and its mirror image are the
+// same position. A transposition is a position entered from at least two different parent
+// positions: the positions that follow it in the same games are not transpositions themselves,
+// since their move orders only differ before the transposition.
+
+// A prefix of a game which reaches a transposition.
+type Occurrence struct {
+	// 0-based index of the game in the archive.
+	Game int
+	// The 1-based column digits of the prefix.
+	Moves string
+}
+
+// A position reached by different move orders.
+type Transposition struct {
+	Key   uint64
+	Plies int
+	// Every distinct prefix reaching the position, with the first game it appears in, sorted
+	// by move sequence.
+	Occurrences []Occurrence
+	// The number of games reaching the position, for frequency statistics.
+	Games int
+}
+
+type node struct {
+	parents     map[uint64]bool
+	occurrences map[string]int
+	games       int
+	plies       int
+}
+
+// Finds every transposition in an archive of games.
+//
+// # Arguments
+//
+// * `games`: The move sequences of the games in either `position.Notation`, from the empty
+// board. Games may end with a winning move.
+//
+// # Returns
+//
+// The transpositions, by number of plies then by key.
+//
+// # Errors
+//
+// Returns an `InvalidGame` wrapping the error of the first illegal move found.
+func Find(games []string) ([]Transposition, error) {
+	nodes := make(map[uint64]*node)
+	for index, moves := range games {
+		game := referee.NewGame()
+		parent := position.NewPosition().GetKey()
+		for i, c := range moves {
+			col, ok := position.ParseColumn(c)
+			if !ok {
+				return nil, InvalidGame{Game: index, Err: position.InvalidCharacter{Character: c, Index: i}}
+			}
+			if _, err := game.Play(col); err != nil {
+				return nil, InvalidGame{Game: index, Err: err}
+			}
+			p := game.GetPosition()
+			key := p.GetKey()
+			n, ok := nodes[key]
+			if !ok {
+				n = &node{parents: make(map[uint64]bool), occurrences: make(map[string]int), plies: p.GetMoves()}
+				nodes[key] = n
+			}
+			n.parents[parent] = true
+			prefix := game.GetMoves()
+			if _, ok := n.occurrences[prefix]; !ok {
+				n.occurrences[prefix] = index
+			}
+			n.games++
+			parent = key
+		}
+	}
+
+	var transpositions []Transposition
+	for key, n := range nodes {
+		if len(n.parents) < 2 {
+			continue
+		}
+		t := Transposition{Key: key, Plies: n.plies, Games: n.games}
+		for prefix, game := range n.occurrences {
+			t.Occurrences = append(t.Occurrences, Occurrence{Game: game, Moves: prefix})
+		}
+		sort.Slice(t.Occurrences, func(i, j int) bool { return t.Occurrences[i].Moves < t.Occurrences[j].Moves })
+		transpositions = append(transpositions, t)
+	}
+	sort.Slice(transpositions, func(i, j int) bool {
+		a, b := transpositions[i], transpositions[j]
+		if a.Plies != b.Plies {
+			return a.Plies < b.Plies
+		}
+		return a.Key < b.Key
+	})
+	return transpositions, nil
+}
+
+// The discs which differ between two positions, by player.
+type Diff struct {
+	// Cells holding a disc of the first or second player in the second position only.
+	Added [2]uint64
+	// Cells holding a disc of the first or second player in the first position only.
+	Removed [2]uint64
+}
+
+// Indicates whether the positions hold the same discs.
+func (self Diff) IsEmpty() bool {
+	return self.Added == [2]uint64{} && self.Removed == [2]uint64{}
+}
+
+// Compares the discs of two positions cell by cell. Mirror images differ, unlike in `Find()`.
+func Compare(a *position.Position, b *position.Position) Diff {
+	var diff Diff
+	first_a, first_b := a.FirstPlayerDiscs(), b.FirstPlayerDiscs()
+	for player, discs := range [2][2]uint64{{first_a, first_b}, {first_a ^ a.GetMask(), first_b ^ b.GetMask()}} {
+		diff.Added[player] = discs[1] &^ discs[0]
+		diff.Removed[player] = discs[0] &^ discs[1]
+	}
+	return diff
+}
@@ -0,0 +1,16 @@
+package transpose
+
+import "fmt"
+
+type InvalidGame struct {
+	Game int
+	Err  error
+}
+
+func (e InvalidGame) Error() string {
+	return fmt.Sprintf("invalid game at index %d: %s", e.Game, e.Err)
+}
+
+func (e InvalidGame) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,57 @@
+package transpose_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/internal/transpose"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+func TestFind(t *testing.T) {
+	games := []string{"1234", "3214", "4444", "32141", "7654"}
+	got, err := transpose.Find(games)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// "123" and "321" reach the same position from different parents. Their continuations
+	// with 4 are not reported again, since they share a parent. "765" is the mirror of "123".
+	want := []transpose.Occurrence{{Game: 0, Moves: "123"}, {Game: 1, Moves: "321"}, {Game: 4, Moves: "765"}}
+	if len(got) != 1 || got[0].Plies != 3 || got[0].Games != 4 || !reflect.DeepEqual(got[0].Occurrences, want) {
+		t.Errorf("Find(%q) = %+v, want one transposition at ply 3 in 4 games with %+v", games, got, want)
+	}
+}
+
+func TestFindWithoutTranspositions(t *testing.T) {
+	got, err := transpose.Find([]string{"1234", "1234", "4444"})
+	if err != nil || len(got) != 0 {
+		t.Errorf("Find() = %+v, %v, want no transpositions for repeated games", got, err)
+	}
+}
+
+func TestFindInvalidGame(t *testing.T) {
+	_, err := transpose.Find([]string{"1234", "1212121 2"})
+	var invalid transpose.InvalidGame
+	if !errors.As(err, &invalid) || invalid.Game != 1 {
+		t.Fatalf("Find() error = %v, want InvalidGame for game 1", err)
+	}
+	if !errors.As(err, new(position.InvalidCharacter)) {
+		t.Errorf("Find() error = %v, want it to wrap InvalidCharacter", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// The first player's disc moves from the first to the fifth column
+	got := transpose.Compare(c4test.Moves(t, "1234"), c4test.Moves(t, "3254"))
+	want := transpose.Diff{Added: [2]uint64{1 << 28, 0}, Removed: [2]uint64{1 << 0, 0}}
+	if got != want {
+		t.Errorf("Compare() = %+v, want %+v", got, want)
+	}
+
+	if !transpose.Compare(c4test.Moves(t, "123"), c4test.Moves(t, "321")).IsEmpty() {
+		t.Error("Compare() found differences between transposed positions")
+	}
+}
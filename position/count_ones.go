@@ -0,0 +1,14 @@
+//go:build amd64 || arm64
+
+package position
+
+import "math/bits"
+
+// The population count implementation selected at build time.
+const PopCount string = "math/bits"
+
+// Counts the set bits of a mask with the hardware POPCNT/CNT instruction, which the compiler
+// emits for `bits.OnesCount64` on these architectures.
+func count_ones(mask uint64) uint8 {
+	return uint8(bits.OnesCount64(mask))
+}
@@ -0,0 +1,18 @@
+//go:build !amd64 && !arm64
+
+package position
+
+// The population count implementation selected at build time.
+const PopCount string = "loop"
+
+// Counts the set bits of a mask by clearing the lowest set bit until none remain.
+// Without a hardware population count this is cheaper than a table lookup, since
+// the threat masks counted by `ScoreMove` rarely have more than a few bits set.
+func count_ones(mask uint64) uint8 {
+	var count uint8 = 0
+	for mask != 0 {
+		mask &= mask - 1
+		count++
+	}
+	return count
+}
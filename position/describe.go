@@ -0,0 +1,86 @@
+package position
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Describes a position in plain sentences, for screen readers and voice assistants.
+//
+// The first player is called Red and the second Yellow. Columns are written in `notation`, and
+// rows are counted from 1 from the bottom. For example, in digits:
+//
+// "Red: column 4 rows 1 to 2, column 5 row 1. Yellow: column 3 row 1, column 4 row 3.
+// Yellow to move. Red threatens column 6 row 1."
+func (self *Position) Describe(notation Notation) string {
+	current, opponent := "Red", "Yellow"
+	if !self.IsFirstPlayerToMove() {
+		current, opponent = opponent, current
+	}
+	red_board := self.FirstPlayerDiscs()
+	opponent_board := self.board ^ self.mask
+
+	var sentences []string
+	for _, player := range []struct {
+		name  string
+		board uint64
+	}{{"Red", red_board}, {"Yellow", red_board ^ self.mask}} {
+		cells := DescribeCells(player.board, notation)
+		if cells == "" {
+			cells = "no discs"
+		}
+		sentences = append(sentences, fmt.Sprintf("%s: %s.", player.name, cells))
+	}
+
+	switch {
+	case compute_won_position(opponent_board):
+		sentences = append(sentences, fmt.Sprintf("%s has connected four.", opponent))
+		return strings.Join(sentences, " ")
+	case self.moves == BoardSize:
+		sentences = append(sentences, "The board is full, the game is a draw.")
+		return strings.Join(sentences, " ")
+	}
+	sentences = append(sentences, fmt.Sprintf("%s to move.", current))
+
+	if wins := self.winning_positions() & self.Possible(); wins != 0 {
+		sentences = append(sentences, fmt.Sprintf("%s can win now at %s.", current, DescribeCells(wins, notation)))
+	}
+	for _, player := range []struct {
+		name    string
+		threats uint64
+	}{{current, self.winning_positions()}, {opponent, self.opponent_winning_position()}} {
+		if player.threats != 0 {
+			sentences = append(sentences, fmt.Sprintf("%s threatens %s.", player.name, DescribeCells(player.threats, notation)))
+		}
+	}
+	return strings.Join(sentences, " ")
+}
+
+// Lists the cells of a mask column by column, merging consecutive rows into ranges,
+// e.g. "column 3 rows 1 to 2, column 5 row 4" in digits or "column c rows 1 to 2, column e row 4"
+// in letters.
+func DescribeCells(mask uint64, notation Notation) string {
+	var parts []string
+	for col := 0; col < W; col++ {
+		var ranges []string
+		for row := 0; row < H; row++ {
+			if mask&(uint64(1)<<(row+col*(H+1))) == 0 {
+				continue
+			}
+			end := row
+			for end+1 < H && mask&(uint64(1)<<(end+1+col*(H+1))) != 0 {
+				end++
+			}
+			if end == row {
+				ranges = append(ranges, fmt.Sprintf("row %d", row+1))
+			} else {
+				ranges = append(ranges, fmt.Sprintf("rows %d to %d", row+1, end+1))
+			}
+			row = end
+		}
+		if len(ranges) > 0 {
+			parts = append(parts, fmt.Sprintf("column %s %s", notation.Column(col), strings.Join(ranges, " and ")))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,29 @@
+package position_test
+
+import (
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		p        *position.Position
+		notation position.Notation
+		want     string
+	}{
+		{c4test.Moves(t, "445"), position.Digits,
+			"Red: column 4 row 1, column 5 row 1. Yellow: column 4 row 2. Yellow to move."},
+		{c4test.Moves(t, "445"), position.Letters,
+			"Red: column d row 1, column e row 1. Yellow: column d row 2. Yellow to move."},
+		// A handicap board with an odd number of discs: x, to move, is the second player
+		{c4test.Grid(t, ".......", ".......", ".......", ".......", ".......", "x.x.o.."), position.Digits,
+			"Red: column 5 row 1. Yellow: column 1 row 1, column 3 row 1. Yellow to move."},
+	}
+	for _, test := range tests {
+		if got := test.p.Describe(test.notation); got != test.want {
+			t.Errorf("Describe(%v) = %q, want %q", test.notation, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package position
+
+// Dense feature planes for machine learning pipelines.
+//
+// Each position is encoded as `FeaturePlanes` planes of H x W values, ordered plane by plane,
+// then row by row from the bottom row, then column by column from the left. Each value is 1 if
+// the cell belongs to the plane's mask and 0 otherwise.
+
+const (
+	// The current player's discs.
+	PlaneCurrent int = iota
+	// The opponent's discs.
+	PlaneOpponent
+	// The cells where the current player can play.
+	PlaneLegal
+	// The empty cells completing a 4-alignment for the current player.
+	PlaneCurrentThreats
+	// The empty cells completing a 4-alignment for the opponent.
+	PlaneOpponentThreats
+
+	FeaturePlanes int = iota
+	FeatureSize   int = FeaturePlanes * BoardSize
+)
+
+// Encodes a batch of positions as feature planes.
+//
+// # Arguments
+//
+// * `dst`: A buffer reused for the output if it has enough capacity, typically the result
+// of a previous call. May be nil.
+// * `positions`: The positions to encode.
+//
+// # Returns
+//
+// A slice of `len(positions) * FeatureSize` values, where the features of the i-th position
+// start at index `i * FeatureSize`.
+func Features(dst []float32, positions []Position) []float32 {
+	n := len(positions) * FeatureSize
+	if cap(dst) < n {
+		dst = make([]float32, n)
+	}
+	dst = dst[:n]
+
+	var masks [FeaturePlanes]uint64
+	for i := range positions {
+		p := &positions[i]
+		masks[PlaneCurrent] = p.board
+		masks[PlaneOpponent] = p.board ^ p.mask
+		masks[PlaneLegal] = p.Possible()
+		masks[PlaneCurrentThreats] = p.winning_positions()
+		masks[PlaneOpponentThreats] = p.opponent_winning_position()
+
+		out := dst[i*FeatureSize : (i+1)*FeatureSize]
+		for plane, mask := range masks {
+			cells := out[plane*BoardSize : (plane+1)*BoardSize]
+			for row := 0; row < H; row++ {
+				for col := 0; col < W; col++ {
+					cells[row*W+col] = float32((mask >> (row + col*(H+1))) & 1)
+				}
+			}
+		}
+	}
+	return dst
+}
@@ -0,0 +1,79 @@
+package position_test
+
+import (
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+func TestFeatures(t *testing.T) {
+	p := c4test.Grid(t,
+		".......",
+		".......",
+		".......",
+		".......",
+		"ooo....",
+		"xxx....",
+	)
+	features := position.Features(nil, []position.Position{*p})
+	if len(features) != position.FeatureSize {
+		t.Fatalf("len(Features()) = %d, want %d", len(features), position.FeatureSize)
+	}
+
+	// Rows are counted from 0 at the bottom, columns from 0 at the left
+	tests := []struct {
+		plane, row, col int
+		want            float32
+	}{
+		{position.PlaneCurrent, 0, 0, 1},
+		{position.PlaneCurrent, 1, 0, 0},
+		{position.PlaneOpponent, 1, 2, 1},
+		{position.PlaneOpponent, 0, 2, 0},
+		{position.PlaneLegal, 2, 0, 1},
+		{position.PlaneLegal, 0, 3, 1},
+		{position.PlaneLegal, 0, 0, 0},
+		{position.PlaneCurrentThreats, 0, 3, 1},
+		{position.PlaneCurrentThreats, 1, 3, 0},
+		{position.PlaneOpponentThreats, 1, 3, 1},
+		{position.PlaneOpponentThreats, 0, 3, 0},
+	}
+	for _, test := range tests {
+		if got := features[test.plane*position.BoardSize+test.row*position.W+test.col]; got != test.want {
+			t.Errorf("plane %d, row %d, column %d = %v, want %v", test.plane, test.row, test.col, got, test.want)
+		}
+	}
+
+	// Counts the cells of each plane, so no cell is set outside the ones checked above
+	want_counts := [position.FeaturePlanes]int{3, 3, 7, 1, 1}
+	for plane, want := range want_counts {
+		count := 0
+		for _, value := range features[plane*position.BoardSize : (plane+1)*position.BoardSize] {
+			count += int(value)
+		}
+		if count != want {
+			t.Errorf("plane %d has %d cells, want %d", plane, count, want)
+		}
+	}
+}
+
+func TestFeaturesReusesTheBuffer(t *testing.T) {
+	positions := []position.Position{*c4test.Moves(t, "4453"), *c4test.Moves(t, "")}
+	buffer := position.Features(nil, positions)
+	for i := range buffer {
+		buffer[i] = -1
+	}
+
+	features := position.Features(buffer, positions[1:])
+	if len(features) != position.FeatureSize || &features[0] != &buffer[0] {
+		t.Fatalf("Features() allocated a new buffer of %d values", len(features))
+	}
+	// Only the legal moves of the empty board are set
+	count := 0
+	for _, value := range features {
+		count += int(value)
+	}
+	if count != position.W {
+		t.Errorf("Features() of the empty board sets %d cells, want %d", count, position.W)
+	}
+}
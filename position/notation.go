@@ -0,0 +1,75 @@
+package position
+
+import "fmt"
+
+// Column notations for move sequences. Parsing accepts both notations, even mixed, so the
+// notation only matters when writing moves.
+
+type Notation int
+
+const (
+	// Columns numbered from 1 at the left, e.g. "4453".
+	Digits Notation = iota
+	// Columns lettered from 'a' at the left, e.g. "ddec".
+	Letters
+)
+
+// Parses a notation from its name, "digits" or "letters".
+func ParseNotation(name string) (Notation, error) {
+	switch name {
+	case "digits":
+		return Digits, nil
+	case "letters":
+		return Letters, nil
+	}
+	return Digits, fmt.Errorf("invalid notation %q: expected digits or letters", name)
+}
+
+func (n Notation) String() string {
+	if n == Letters {
+		return "letters"
+	}
+	return "digits"
+}
+
+// Parses a column written in either notation.
+//
+// # Returns
+//
+// The 0-based index of the column, and false if the character is not a column of the board in
+// either notation, e.g. '8' or 'z'.
+func ParseColumn(c rune) (int, bool) {
+	col := -1
+	switch {
+	case c >= '1' && c <= '9':
+		col = int(c - '1')
+	case c >= 'a' && c <= 'z':
+		col = int(c - 'a')
+	case c >= 'A' && c <= 'Z':
+		col = int(c - 'A')
+	}
+	if col < 0 || col >= W {
+		return 0, false
+	}
+	return col, true
+}
+
+// Writes a 0-based column index in this notation.
+func (n Notation) Column(col int) string {
+	if n == Letters {
+		return string(rune('a' + col))
+	}
+	return string(rune('1' + col))
+}
+
+// Rewrites a move sequence in this notation. Characters which are not columns are kept as is.
+func (n Notation) Format(move_sequence string) string {
+	var result []rune
+	for _, c := range move_sequence {
+		if col, ok := ParseColumn(c); ok {
+			c = []rune(n.Column(col))[0]
+		}
+		result = append(result, c)
+	}
+	return string(result)
+}
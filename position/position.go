@@ -0,0 +1,428 @@
+package position
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// Represents a Connect Four position compactly as a bitboard.
+//
+// The standard, 6x7 Connect Four board can be represented unambiguously using 49 bits
+// in the following bit order:
+//
+// ```comment
+//   6 13 20 27 34 41 48
+//  ---------------------
+// | 5 12 19 26 33 40 47 |
+// | 4 11 18 25 32 39 46 |
+// | 3 10 17 24 31 38 45 |
+// | 2  9 16 23 30 37 44 |
+// | 1  8 15 22 29 36 43 |
+// | 0  7 14 21 28 35 42 |
+//  ---------------------
+//```
+//
+// The extra row of bits at the top identifies full columns and prevents bits from overflowing
+// into the next column. For computational efficiency, positions are stored in practice using two
+// `uint64` numbers: one to store a mask of all occupied tiles, and the other to store a mask of the
+// current player's tiles. The same two masks are also maintained for the mirrored board, so the
+// canonical key of a position is available without recomputing the mirror after every move.
+
+const (
+	W         int = 7
+	H         int = 6
+	BoardSize int = W * H
+	Centre    int = W / 2
+	MinScore  int = -(BoardSize)/2 + 3
+	MaxScore  int = (BoardSize+1)/2 - 3
+)
+
+// A Connect 4 position. The masks are only changed through methods such as `Play()` and
+// `Unplay()`, which also keep the mirrored masks used by `GetKey()` in sync with them.
+type Position struct {
+	board uint64
+	mask  uint64
+	moves int
+
+	mirrored_board uint64
+	mirrored_mask  uint64
+}
+
+// a mask for the bottom row of the board.
+func bottom_mask() uint64 {
+	var mask uint64 = 0
+	for i := 0; i < W; i++ {
+		mask |= bottom_mask_col(i)
+	}
+	return mask
+}
+
+// A mask for all positions excluding the extra overflow row.
+func board_mask() uint64 {
+	return bottom_mask() * ((1 << H) - 1)
+}
+
+// Creates a new `Position` instance for the initial state of the game.
+func NewPosition() *Position {
+	p := &Position{
+		board: 0,
+		mask:  0,
+		moves: 0,
+	}
+	return p
+}
+
+// Parses a `Position` from a string representation of a Connect Four board.
+//
+// The input string should contain exactly 42 character from the set ['.', 'o', 'x'],
+// representing the board row by row from the top-left to the bottom-right. All other characters
+// are ignored. 'x' is the current player, and 'o' is the opponent.
+// The numbers of discs of each player are not checked, so handicap setups where one side starts
+// with extra discs are accepted. Either way, 'x' is the first player when the number of discs is
+// even, see `IsFirstPlayerToMove()`. Positions where either player has already won are accepted too,
+// but must not be played from.
+//
+// # Arguments
+//
+// * `board_string`: A string slice representing the board state.
+//
+// # Returns
+//
+// On success, returns a `Result` containing the parsed `Position`.
+//
+// # Errors
+//
+// Returns a `Error()` if the input string is invalid, or if a disc sits above an empty cell.
+
+func PositionFromBoardString(board_string string) (*Position, error) {
+	board_string = strings.ToLower(board_string)
+	var chars []rune
+	for _, c := range board_string {
+		if c == '.' || c == 'o' || c == 'x' {
+			chars = append(chars, c)
+		}
+	}
+
+	// Validates exact number of `chars` required for a full board
+	if len(chars) != BoardSize {
+		return nil, InvalidBoardStringLength{Actual: len(chars), Expected: BoardSize}
+	}
+
+	var board uint64 = 0
+	var mask uint64 = 0
+	var moves int = 0
+
+	for i, c := range chars {
+		if c == '.' {
+			continue
+		}
+
+		var row int = H - (i / W) - 1
+		var col int = i % W
+
+		var bit_index int = row + col*(H+1)
+		var board_bit uint64
+		if c == 'x' {
+			board_bit = 1
+		} else {
+			board_bit = 0
+		}
+
+		board |= board_bit << bit_index
+		mask |= uint64(1) << uint64(bit_index)
+		moves += 1
+	}
+
+	for col := 0; col < W; col++ {
+		// The discs of a column must form a contiguous run from the bottom
+		column := mask & ColumnMask(col)
+		if column&(column+bottom_mask_col(col)) != 0 {
+			return nil, InvalidFloatingDisc{Column: col + 1}
+		}
+	}
+
+	p := &Position{board: board, mask: mask, moves: moves}
+	p.mirrored_board, p.mirrored_mask = p.get_mirrored_bitmasks()
+	return p, nil
+}
+
+// Parses a `Position` by playing a sequence of moves from the initial state of the game.
+//
+// Each character of the sequence is the column played, either as a 1-based digit or as a
+// letter from 'a', e.g. "4453" and "ddec" both play twice in the centre column, then in the
+// fifth and third columns.
+//
+// # Arguments
+//
+// * `move_sequence`: A string slice of columns in either `Notation`.
+//
+// # Returns
+//
+// On success, returns the `Position` reached after playing every move.
+//
+// # Errors
+//
+// Returns an `InvalidCharacter` if a character is not a column of the board, e.g. '8' or 'z', and
+// another error if a column is full, a move completes a 4-alignment, or the sequence is empty.
+func PositionFromMoves(move_sequence string) (*Position, error) {
+	var position *Position = NewPosition()
+	var col int = -1
+
+	for i, c := range move_sequence {
+		var ok bool
+		if col, ok = ParseColumn(c); !ok {
+			return nil, InvalidCharacter{Character: c, Index: i}
+		}
+		if !position.IsPlayable(col) {
+			return nil, InvalidFullColumnMove{Column: col + 1, Index: i}
+		}
+		if position.IsWinningMove(col) {
+			return nil, InvalidWinningMove{Column: col + 1, Index: i}
+		}
+		position.Play(col)
+	}
+	if col == -1 {
+		return nil, InvalidColumn{Column: col}
+	}
+	return position, nil
+}
+
+// Renders the board in the format read by `PositionFromBoardString`: one line per row from the
+// top, with 'x' for the current player, 'o' for the opponent and '.' for empty cells.
+func (self *Position) String() string {
+	var sb strings.Builder
+	sb.Grow(BoardSize + H)
+	for row := H - 1; row >= 0; row-- {
+		for col := 0; col < W; col++ {
+			bit := uint64(1) << (row + col*(H+1))
+			switch {
+			case self.board&bit != 0:
+				sb.WriteByte('x')
+			case self.mask&bit != 0:
+				sb.WriteByte('o')
+			default:
+				sb.WriteByte('.')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func (self *Position) GetMoves() int {
+	return self.moves
+}
+
+// Indicates whether the first player is to move. Players alternate from the empty board, so
+// the first player moves whenever the number of discs is even. Boards parsed from a string
+// follow the same rule, including handicap setups where one side has extra discs.
+func (self *Position) IsFirstPlayerToMove() bool {
+	return self.moves%2 == 0
+}
+
+// Returns a mask of the first player's discs, see `IsFirstPlayerToMove()`.
+func (self *Position) FirstPlayerDiscs() uint64 {
+	if self.IsFirstPlayerToMove() {
+		return self.board
+	}
+	return self.board ^ self.mask
+}
+
+// Returns a mask of the current player's discs.
+func (self *Position) GetBoard() uint64 {
+	return self.board
+}
+
+// Returns a mask of every disc on the board.
+func (self *Position) GetMask() uint64 {
+	return self.mask
+}
+
+func (self *Position) GetKey() uint64 {
+	// Calculates the standard key for a position
+	key := self.board + self.mask
+
+	// Calculates the key of the mirrored position
+	mirrored_key := self.mirrored_board + self.mirrored_mask
+
+	if mirrored_key < key {
+		return mirrored_key
+	}
+	return key
+}
+
+func (self *Position) get_mirrored_bitmasks() (uint64, uint64) {
+	var mirrored_position uint64 = 0
+	var mirrored_mask uint64 = 0
+
+	// Swaps columns within the position and mask up to the centre column
+	for col := 0; col < Centre; col++ {
+		mirrored_col := W - 1 - col
+		shift := (mirrored_col - col) * (H + 1)
+		mirrored_position |= ((self.board & ColumnMask(col)) << uint64(shift)) |
+			((self.board & ColumnMask(mirrored_col)) >> uint64(shift))
+		mirrored_mask |= ((self.mask & ColumnMask(col)) << uint64(shift)) |
+			((self.mask & ColumnMask(mirrored_col)) >> uint64(shift))
+	}
+
+	if W&1 == 1 {
+		mirrored_position |= self.board & ColumnMask(Centre)
+		mirrored_mask |= self.mask & ColumnMask(Centre)
+	}
+
+	return mirrored_position, mirrored_mask
+}
+
+// Indicates whether a given column is playable
+//
+// # Arguments
+// `col`: 0-based index of a column
+//
+// # Returns
+//
+// True if the column is playable, false if the column is already full
+func (self *Position) IsPlayable(col int) bool {
+	return self.mask&top_mask_col(col) == 0
+}
+
+// Indicates whether the current player can win with their next move.
+// # Arguments
+// `col`: 0-based index of a playable column
+//
+// # Returns
+//
+// True if the current player make a 4-alignment by playing the column, false if not
+func (self *Position) IsWinningMove(col int) bool {
+	return self.winning_positions()&self.Possible()&ColumnMask(col) > 0
+}
+
+// Indicates if the current player can win on their next turn
+func (self *Position) CanWinNext() bool {
+	return self.winning_positions()&self.Possible() > 0
+}
+
+// Plays a move in the given column
+//
+// # Arguments
+// `col`: 0-based index of a playable column#
+func (self *Position) Play(col int) {
+	// Switches the bits of the current and opponent player
+	self.board ^= self.mask
+
+	// Adds an extra mask bit to the played column
+	self.mask |= self.mask + bottom_mask_col(col)
+
+	self.mirrored_board ^= self.mirrored_mask
+	self.mirrored_mask |= self.mirrored_mask + bottom_mask_col(W-1-col)
+
+	self.moves += 1
+}
+
+// Plays a move given as a bitmask
+//
+// # Arguments
+// `move_bit`: a mask with a single bit set on a playable cell, e.g. a bit from `Possible()`
+func (self *Position) PlayMove(move_bit uint64) {
+	self.board ^= self.mask
+	self.mask |= move_bit
+
+	index := bits.TrailingZeros64(move_bit)
+	col, row := index/(H+1), index%(H+1)
+	self.mirrored_board ^= self.mirrored_mask
+	self.mirrored_mask |= uint64(1) << (row + (W-1-col)*(H+1))
+
+	self.moves += 1
+}
+
+// Takes back the last move, which must have been played in the given column
+//
+// # Arguments
+// `col`: 0-based index of the column of the last move
+func (self *Position) Unplay(col int) {
+	// The top disc of a column sits just below the column's next free cell
+	self.mask ^= ((self.mask & ColumnMask(col)) + bottom_mask_col(col)) >> 1
+	self.board ^= self.mask
+
+	mirrored_col := W - 1 - col
+	self.mirrored_mask ^= ((self.mirrored_mask & ColumnMask(mirrored_col)) + bottom_mask_col(mirrored_col)) >> 1
+	self.mirrored_board ^= self.mirrored_mask
+
+	self.moves -= 1
+}
+
+// Returns a mask for the positionsible moves the current player can make
+func (self *Position) Possible() uint64 {
+	return (self.mask + bottom_mask()) & board_mask()
+}
+
+// Returns a mask for the positionsible non losing moves the current player can make
+func (self *Position) PossibleNonLosingMoves() uint64 {
+	possible := self.Possible()
+	opponent_wins := self.opponent_winning_position()
+
+	// Checks if there are any forced moves to avoid the opponent winning
+	forced_moves := possible & opponent_wins
+	if forced_moves > 0 {
+		if forced_moves&(forced_moves-1) > 0 {
+			// If the opponent has two winning moves then they can't be stopped
+			return 0
+		} else {
+			possible = forced_moves
+		}
+	}
+
+	// Avoid playing below any of the opponent's winning positions
+	return possible & ^(uint64(opponent_wins) >> 1)
+}
+
+func (self *Position) winning_positions() uint64 {
+	return compute_winning_position(self.board, self.mask)
+}
+
+func (self *Position) opponent_winning_position() uint64 {
+	return compute_winning_position(self.board^self.mask, self.mask)
+}
+
+// Returns a mask of the empty cells where the current player would complete a 4-alignment,
+// including cells which cannot be played yet
+func (self *Position) Threats() uint64 {
+	return self.winning_positions()
+}
+
+// Returns a mask of the empty cells where the opponent would complete a 4-alignment,
+// including cells which cannot be played yet
+func (self *Position) OpponentThreats() uint64 {
+	return self.opponent_winning_position()
+}
+
+func (self *Position) ScoreMove(move_bit uint64) uint8 {
+	return count_ones(compute_winning_position(self.board|move_bit, self.mask))
+}
+
+func (self *Position) IsWonPosition() bool {
+	return compute_won_position(self.board) || compute_won_position(self.board^self.mask)
+}
+
+func top_mask_col(col int) uint64 {
+	return uint64(1) << (H - 1 + col*(H+1))
+}
+
+func bottom_mask_col(col int) uint64 {
+	return uint64(1) << (col * (H + 1))
+}
+
+// Returns a mask for all playable cells of a column
+func ColumnMask(col int) uint64 {
+	return ((uint64(1) << H) - 1) << (col * (H + 1))
+}
+
+// Returns the columns from the centre outwards, alternating sides and starting on the left,
+// e.g. 3, 2, 4, 1, 5, 0, 6. Central discs take part in more alignments, so this is the order in
+// which moves are searched and in which ties between equally good moves are broken.
+func CentreFirst() [W]int {
+	var order [W]int
+	for i := 0; i < W; i++ {
+		order[i] = Centre + (1-2*(i%2))*(i+1)/2
+	}
+	return order
+}
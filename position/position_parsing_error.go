@@ -27,6 +27,10 @@ type InvalidWinningMove struct {
 	Index  int
 }
 
+type InvalidFloatingDisc struct {
+	Column int
+}
+
 func (e InvalidBoardStringLength) Error() string {
 	return fmt.Sprintf("invalid board string length: found %d, expected %d", e.Actual, e.Expected)
 }
@@ -46,3 +50,7 @@ func (e InvalidFullColumnMove) Error() string {
 func (e InvalidWinningMove) Error() string {
 	return fmt.Sprintf("invalid move at index %d: column %d results in a win", e.Index, e.Column)
 }
+
+func (e InvalidFloatingDisc) Error() string {
+	return fmt.Sprintf("invalid board: column %d has a disc above an empty cell", e.Column)
+}
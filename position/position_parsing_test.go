@@ -0,0 +1,28 @@
+package position_test
+
+import (
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+func TestPositionFromMoves(t *testing.T) {
+	tests := []struct {
+		moves string
+		err   error
+	}{
+		{"4453", nil},
+		{"ddec", nil},
+		{"448", position.InvalidCharacter{Character: '8', Index: 2}},
+		{"dz", position.InvalidCharacter{Character: 'z', Index: 1}},
+		{"40", position.InvalidCharacter{Character: '0', Index: 1}},
+		{"4444444", position.InvalidFullColumnMove{Column: 4, Index: 6}},
+		{"1212121", position.InvalidWinningMove{Column: 1, Index: 6}},
+		{"44-", position.InvalidCharacter{Character: '-', Index: 2}},
+	}
+	for _, test := range tests {
+		if _, err := position.PositionFromMoves(test.moves); err != test.err {
+			t.Errorf("PositionFromMoves(%q) error = %v, want %v", test.moves, err, test.err)
+		}
+	}
+}
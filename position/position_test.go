@@ -0,0 +1,61 @@
+package position_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4quick"
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+func TestUnplayRestoresThePreviousPosition(t *testing.T) {
+	c4quick.Check(t, func(g c4quick.Game) bool {
+		if g.Moves == "" {
+			return true
+		}
+		last, _ := position.ParseColumn(rune(g.Moves[len(g.Moves)-1]))
+		p := *g.Position
+		p.Unplay(last)
+		return p == *c4test.Moves(t, g.Moves[:len(g.Moves)-1])
+	}, &c4quick.Config{Seed: 1})
+}
+
+func TestPlayMoveMatchesPlay(t *testing.T) {
+	c4quick.Check(t, func(g c4quick.Game) bool {
+		for col := 0; col < position.W; col++ {
+			if !g.Position.IsPlayable(col) {
+				continue
+			}
+			a, b := *g.Position, *g.Position
+			a.Play(col)
+			b.PlayMove(g.Position.Possible() & position.ColumnMask(col))
+			if a != b {
+				return false
+			}
+		}
+		return true
+	}, &c4quick.Config{Seed: 1})
+}
+
+func TestIncrementalKeyMatchesRecomputedKey(t *testing.T) {
+	c4quick.Check(t, func(g c4quick.Game) bool {
+		// Parsing a board computes the mirrored masks from scratch
+		p, err := position.PositionFromBoardString(g.Position.String())
+		return err == nil && p.GetKey() == g.Position.GetKey()
+	}, &c4quick.Config{Seed: 1})
+}
+
+func TestMirroredPositionsShareTheirKey(t *testing.T) {
+	c4quick.Check(t, func(g c4quick.Game) bool {
+		mirrored := strings.Map(func(c rune) rune { return '1' + '7' - c }, g.Moves)
+		return c4test.Moves(t, mirrored).GetKey() == g.Position.GetKey()
+	}, &c4quick.Config{Seed: 1})
+}
+
+func TestCentreFirst(t *testing.T) {
+	want := [position.W]int{3, 2, 4, 1, 5, 0, 6}
+	if got := position.CentreFirst(); got != want {
+		t.Errorf("CentreFirst() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,19 @@
+package position
+
+// A win detection implementation, exposed so that implementations can be compared.
+type WinDetector struct {
+	Name string
+
+	// Computes a mask of the cells completing a 4-alignment for a player.
+	// Takes a bitmask of the player's discs and a bitmask of all discs.
+	WinningPositions func(position uint64, mask uint64) uint64
+
+	// Indicates whether a player's discs contain a 4-alignment.
+	IsWon func(position uint64) bool
+}
+
+// Every available win detection, whichever one is selected at build time.
+var WinDetectors = []WinDetector{
+	{"shift", shift_winning_position, shift_won_position},
+	{"table", table_winning_position, table_won_position},
+}
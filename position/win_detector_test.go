@@ -0,0 +1,69 @@
+package position_test
+
+import (
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/generate"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Returns reproducible random positions from every stage of the game.
+func random_positions(tb testing.TB, count int) []*position.Position {
+	tb.Helper()
+	g := generate.NewGenerator(1)
+	positions := make([]*position.Position, count)
+	for i := range positions {
+		_, p, err := g.RandomGame(i % (position.BoardSize + 1))
+		if err != nil {
+			tb.Fatal(err)
+		}
+		positions[i] = p
+	}
+	return positions
+}
+
+func TestWinDetectorsAgree(t *testing.T) {
+	reference := position.WinDetectors[0]
+	for _, p := range random_positions(t, 10000) {
+		// Checks both players' discs, since generated positions are never won
+		for _, board := range []uint64{p.GetBoard(), p.GetBoard() ^ p.GetMask()} {
+			want_winning, want_won := reference.WinningPositions(board, p.GetMask()), reference.IsWon(board|p.Possible())
+			for _, detector := range position.WinDetectors[1:] {
+				if got := detector.WinningPositions(board, p.GetMask()); got != want_winning {
+					t.Fatalf("%s: WinningPositions(%#x, %#x) = %#x, %s gives %#x", detector.Name, board, p.GetMask(), got, reference.Name, want_winning)
+				}
+				if got := detector.IsWon(board | p.Possible()); got != want_won {
+					t.Fatalf("%s: IsWon(%#x) = %v, %s gives %v", detector.Name, board|p.Possible(), got, reference.Name, want_won)
+				}
+			}
+		}
+	}
+}
+
+var sink uint64
+
+func BenchmarkWinningPositions(b *testing.B) {
+	positions := random_positions(b, 1000)
+	for _, detector := range position.WinDetectors {
+		b.Run(detector.Name, func(b *testing.B) {
+			for i := 0; b.Loop(); i++ {
+				p := positions[i%len(positions)]
+				sink ^= detector.WinningPositions(p.GetBoard(), p.GetMask())
+			}
+		})
+	}
+}
+
+func BenchmarkIsWon(b *testing.B) {
+	positions := random_positions(b, 1000)
+	for _, detector := range position.WinDetectors {
+		b.Run(detector.Name, func(b *testing.B) {
+			for i := 0; b.Loop(); i++ {
+				p := positions[i%len(positions)]
+				if detector.IsWon(p.GetBoard() | p.Possible()) {
+					sink++
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !c4tables
+
+package position
+
+// The name of the win detection selected at build time.
+const WinDetection string = "shift"
+
+func compute_winning_position(position uint64, mask uint64) uint64 {
+	return shift_winning_position(position, mask)
+}
+
+func compute_won_position(position uint64) bool {
+	return shift_won_position(position)
+}
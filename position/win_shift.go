@@ -0,0 +1,73 @@
+package position
+
+// Win detection using shifted copies of a player's bitmask, one pair of shifts per direction.
+
+// Computes a mask for all of a player's winning positions
+// Equivalent to a mask of all open ended 3-alignments
+// including unreachable floating positions
+//
+// # Arguments
+// * `position`: Bitmask for a player's occupied positions.
+// * `mask`: Bitmask for all occupied positions.
+//
+// # Returns
+//
+// A bitmask with ones in all positions that a piece could be played by the player to win
+func shift_winning_position(position uint64, mask uint64) uint64 {
+	// Vertical alignment
+	var r uint64 = (position << 1) & (position << 2) & (position << 3)
+
+	// Horizontal alignment
+	var p uint64 = (position << (H + 1)) & (position << (2 * (H + 1)))
+	r |= p & (position << (3 * (H + 1)))
+	r |= p & (position >> (H + 1))
+	p >>= 3 * (H + 1)
+	r |= p & (position << (H + 1))
+	r |= p & (position >> (3 * (H + 1)))
+
+	// Diag alignment 1
+	var p2 uint64 = (position << H) & (position << (2 * H))
+	r |= p2 & (position << (3 * H))
+	r |= p2 & (position >> H)
+	p2 >>= 3 * H
+	r |= p2 & (position << H)
+	r |= p2 & (position >> (3 * H))
+
+	// Diagonal alignment 2
+	var p3 uint64 = (position << (H + 2)) & (position << (2 * (H + 2)))
+	r |= p3 & (position << (3 * (H + 2)))
+	r |= p3 & (position >> (H + 2))
+	p3 >>= 3 * (H + 2)
+	r |= p3 & (position << (H + 2))
+	r |= p3 & (position >> (3 * (H + 2)))
+
+	return r & (board_mask() ^ mask)
+}
+
+// Indicates whether a player's discs contain a 4-alignment
+func shift_won_position(position uint64) bool {
+	// Horizontal alignment
+	var m uint64 = position & (position >> (H + 1))
+	if m&(m>>(2*(H+1))) > 0 {
+		return true
+	}
+
+	// Diagonal alignment 1
+	var m2 uint64 = position & (position >> H)
+	if m2&(m2>>(2*H)) > 0 {
+		return true
+	}
+
+	// Diagonal alignment 2
+	var m3 uint64 = position & (position >> (H + 2))
+	if m3&(m3>>(2*(H+2))) > 0 {
+		return true
+	}
+
+	// Vertical alignment
+	var m4 uint64 = position & (position >> 1)
+	if m4&(m4>>2) > 0 {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package position
+
+// Win detection using a precomputed table of every 4-alignment on the board.
+//
+// Each alignment is checked independently with branchless bit arithmetic instead of shifting
+// the whole board per direction. Which detection is used by `Position` is chosen at build time
+// with the `c4tables` build tag.
+
+// Bitmasks of the 4 cells of every horizontal, vertical and diagonal alignment.
+var alignments []uint64 = compute_alignments()
+
+func compute_alignments() []uint64 {
+	var result []uint64
+	directions := [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+	for col := 0; col < W; col++ {
+		for row := 0; row < H; row++ {
+			for _, d := range directions {
+				end_col, end_row := col+3*d[0], row+3*d[1]
+				if end_col >= W || end_row < 0 || end_row >= H {
+					continue
+				}
+				var alignment uint64 = 0
+				for i := 0; i < 4; i++ {
+					alignment |= uint64(1) << ((row + i*d[1]) + (col+i*d[0])*(H+1))
+				}
+				result = append(result, alignment)
+			}
+		}
+	}
+	return result
+}
+
+// Computes a mask for all of a player's winning positions, like `shift_winning_position`.
+func table_winning_position(position uint64, mask uint64) uint64 {
+	var r uint64 = 0
+	for _, alignment := range alignments {
+		// A single missing cell completes the alignment
+		missing := alignment &^ position
+		rest := missing & (missing - 1)
+		r |= missing & (((rest | -rest) >> 63) - 1)
+	}
+	return r & (board_mask() ^ mask)
+}
+
+// Indicates whether a player's discs contain a 4-alignment, like `shift_won_position`.
+func table_won_position(position uint64) bool {
+	var missing uint64 = 1
+	for _, alignment := range alignments {
+		missing &= bits_missing(alignment, position)
+	}
+	return missing == 0
+}
+
+// Returns 0 if all bits of `alignment` are set in `position`, or 1 otherwise.
+func bits_missing(alignment uint64, position uint64) uint64 {
+	m := alignment &^ position
+	return (m | -m) >> 63
+}
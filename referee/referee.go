@@ -0,0 +1,237 @@
+package referee
+
+import (
+	"strings"
+
+	"github.com/YKhan142008/c4-solver/events"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Enforces the rules of a Connect Four game on behalf of a game host.
+//
+// A `Game` validates each proposed move against the current state, applies it, and reports the
+// resulting status. Unlike `position.PositionFromMoves`, games may end with a winning move, and
+// no move is accepted once the game is over. Games start from the empty board, or from any
+// board without a 4-alignment, including handicap setups where one side has extra discs.
+
+type Status int
+
+const (
+	InProgress Status = iota
+	FirstPlayerWin
+	SecondPlayerWin
+	Draw
+)
+
+func (s Status) String() string {
+	switch s {
+	case InProgress:
+		return "in progress"
+	case FirstPlayerWin:
+		return "first player wins"
+	case SecondPlayerWin:
+		return "second player wins"
+	case Draw:
+		return "draw"
+	}
+	return "unknown"
+}
+
+// Indicates whether no further moves can be played.
+func (s Status) IsOver() bool {
+	return s != InProgress
+}
+
+// How a game ended.
+type Reason int
+
+const (
+	// The game is still in progress.
+	NotOver Reason = iota
+	// A player completed a 4-alignment.
+	Connected
+	// The board filled up without a 4-alignment.
+	BoardFull
+	// A player resigned.
+	Resignation
+	// Both players agreed to a draw.
+	Agreement
+)
+
+func (r Reason) String() string {
+	switch r {
+	case NotOver:
+		return "not over"
+	case Connected:
+		return "connected four"
+	case BoardFull:
+		return "board full"
+	case Resignation:
+		return "resignation"
+	case Agreement:
+		return "agreement"
+	}
+	return "unknown"
+}
+
+type Game struct {
+	position *position.Position
+	moves    strings.Builder
+	status   Status
+	reason   Reason
+	events   *events.Bus
+}
+
+// Creates a new `Game` from the initial state.
+func NewGame() *Game {
+	return &Game{position: position.NewPosition()}
+}
+
+// Replays a sequence of columns in either `position.Notation` into a new `Game`.
+//
+// # Errors
+//
+// Returns the first error reported by `Play()`, or a `position.InvalidCharacter` if the
+// sequence contains a character which is not a column.
+func GameFromMoves(move_sequence string) (*Game, error) {
+	game := NewGame()
+	for i, c := range move_sequence {
+		col, ok := position.ParseColumn(c)
+		if !ok {
+			return nil, position.InvalidCharacter{Character: c, Index: i}
+		}
+		if _, err := game.Play(col); err != nil {
+			return nil, err
+		}
+	}
+	return game, nil
+}
+
+// Publishes an `events.MovePlayed` on the bus for every move played from now on. A nil bus
+// stops publishing.
+func (self *Game) SetEvents(bus *events.Bus) {
+	self.events = bus
+}
+
+// Creates a new `Game` from a board in the format of `position.PositionFromBoardString`.
+//
+// As for every position, the first player is the one to move when the number of discs is
+// even, see `position.Position.IsFirstPlayerToMove()`. So 'x', the player to move, is the second
+// player on boards with an odd number of discs. `GetMoves()` only returns the moves played after
+// the starting board.
+//
+// # Errors
+//
+// Returns the error of `position.PositionFromBoardString` if the board is invalid, or a
+// `WonStart` if either player already has a 4-alignment.
+func GameFromBoard(board_string string) (*Game, error) {
+	p, err := position.PositionFromBoardString(board_string)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsWonPosition() {
+		return nil, WonStart{}
+	}
+	game := &Game{position: p}
+	if p.GetMoves() == position.BoardSize {
+		game.status, game.reason = Draw, BoardFull
+	}
+	return game, nil
+}
+
+// Validates and plays a move for the player to move.
+//
+// # Arguments
+//
+// * `col`: 0-based index of a column.
+//
+// # Returns
+//
+// The status of the game after the move.
+//
+// # Errors
+//
+// Returns a `GameOver` error if the game has already ended, or a `position.InvalidColumn` or
+// `position.InvalidFullColumnMove` error indexed by the number of moves played in the game if the
+// move is illegal. The game is left unchanged on error.
+func (self *Game) Play(col int) (Status, error) {
+	ply := self.moves.Len()
+	if self.status.IsOver() {
+		return self.status, GameOver{Status: self.status, Index: ply}
+	}
+	if col < 0 || col >= position.W {
+		return self.status, position.InvalidColumn{Column: col + 1, Index: ply}
+	}
+	if !self.position.IsPlayable(col) {
+		return self.status, position.InvalidFullColumnMove{Column: col + 1, Index: ply}
+	}
+
+	won := self.position.IsWinningMove(col)
+	first := self.position.IsFirstPlayerToMove()
+	self.position.Play(col)
+	self.moves.WriteByte(byte('1' + col))
+
+	switch {
+	case won && first:
+		self.status, self.reason = FirstPlayerWin, Connected
+	case won:
+		self.status, self.reason = SecondPlayerWin, Connected
+	case self.position.GetMoves() == position.BoardSize:
+		self.status, self.reason = Draw, BoardFull
+	}
+	self.events.Publish(events.Event{Kind: events.MovePlayed, Position: *self.position, Column: col})
+	return self.status, nil
+}
+
+// Ends the game with a win for the opponent of the player to move.
+//
+// # Errors
+//
+// Returns a `GameOver` error if the game has already ended.
+func (self *Game) Resign() (Status, error) {
+	if self.status.IsOver() {
+		return self.status, GameOver{Status: self.status, Index: self.moves.Len()}
+	}
+	self.status, self.reason = FirstPlayerWin, Resignation
+	if self.position.IsFirstPlayerToMove() {
+		self.status = SecondPlayerWin
+	}
+	return self.status, nil
+}
+
+// Ends the game with a draw agreed by both players.
+//
+// # Errors
+//
+// Returns a `GameOver` error if the game has already ended.
+func (self *Game) AgreeDraw() (Status, error) {
+	if self.status.IsOver() {
+		return self.status, GameOver{Status: self.status, Index: self.moves.Len()}
+	}
+	self.status, self.reason = Draw, Agreement
+	return self.status, nil
+}
+
+// Indicates whether a move would be accepted by `Play()`.
+func (self *Game) IsLegal(col int) bool {
+	return !self.status.IsOver() && col >= 0 && col < position.W && self.position.IsPlayable(col)
+}
+
+func (self *Game) GetStatus() Status {
+	return self.status
+}
+
+func (self *Game) GetReason() Reason {
+	return self.reason
+}
+
+// Returns the 1-based column digits of every move played so far.
+func (self *Game) GetMoves() string {
+	return self.moves.String()
+}
+
+// Returns a copy of the current position, with the player to move as the current player.
+func (self *Game) GetPosition() *position.Position {
+	p := *self.position
+	return &p
+}
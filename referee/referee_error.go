@@ -0,0 +1,18 @@
+package referee
+
+import "fmt"
+
+type GameOver struct {
+	Status Status
+	Index  int
+}
+
+type WonStart struct{}
+
+func (e GameOver) Error() string {
+	return fmt.Sprintf("invalid move at index %d: game is over (%s)", e.Index, e.Status)
+}
+
+func (e WonStart) Error() string {
+	return "invalid starting position: a player has already won"
+}
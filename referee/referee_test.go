@@ -0,0 +1,121 @@
+package referee_test
+
+import (
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/referee"
+)
+
+// A game filling the board without a 4-alignment.
+const drawn_game = "162774561126356433137261227361424735755544"
+
+func TestGameStatuses(t *testing.T) {
+	tests := []struct {
+		moves  string
+		status referee.Status
+		reason referee.Reason
+	}{
+		{"", referee.InProgress, referee.NotOver},
+		{"444", referee.InProgress, referee.NotOver},
+		{"1212121", referee.FirstPlayerWin, referee.Connected},
+		{"12121232", referee.SecondPlayerWin, referee.Connected},
+		{drawn_game, referee.Draw, referee.BoardFull},
+	}
+	for _, test := range tests {
+		game, err := referee.GameFromMoves(test.moves)
+		if err != nil {
+			t.Fatalf("GameFromMoves(%q): %v", test.moves, err)
+		}
+		if game.GetStatus() != test.status || game.GetReason() != test.reason {
+			t.Errorf("GameFromMoves(%q) = %s by %s, want %s by %s",
+				test.moves, game.GetStatus(), game.GetReason(), test.status, test.reason)
+		}
+	}
+}
+
+func TestNoMoveAfterTheGameEnds(t *testing.T) {
+	game, err := referee.GameFromMoves("1212121")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.IsLegal(1) {
+		t.Error("IsLegal(1) = true after a win")
+	}
+	if _, err := game.Play(1); err != (referee.GameOver{Status: referee.FirstPlayerWin, Index: 7}) {
+		t.Errorf("Play(1) error = %v, want GameOver", err)
+	}
+	if _, err := game.Resign(); err == nil {
+		t.Error("Resign() succeeded after a win")
+	}
+	if game.GetMoves() != "1212121" {
+		t.Errorf("GetMoves() = %q, want the moves before the error", game.GetMoves())
+	}
+}
+
+func TestIllegalMoves(t *testing.T) {
+	game, err := referee.GameFromMoves("444444")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := game.Play(3); err != (position.InvalidFullColumnMove{Column: 4, Index: 6}) {
+		t.Errorf("Play(3) error = %v, want InvalidFullColumnMove", err)
+	}
+	if _, err := game.Play(position.W); err != (position.InvalidColumn{Column: position.W + 1, Index: 6}) {
+		t.Errorf("Play(%d) error = %v, want InvalidColumn", position.W, err)
+	}
+}
+
+func TestResignationAndAgreement(t *testing.T) {
+	tests := []struct {
+		moves  string
+		resign referee.Status
+	}{
+		{"", referee.SecondPlayerWin},
+		{"4", referee.FirstPlayerWin},
+	}
+	for _, test := range tests {
+		game, _ := referee.GameFromMoves(test.moves)
+		if status, err := game.Resign(); err != nil || status != test.resign || game.GetReason() != referee.Resignation {
+			t.Errorf("%q: Resign() = %s, %v by %s, want %s by resignation", test.moves, status, err, game.GetReason(), test.resign)
+		}
+
+		game, _ = referee.GameFromMoves(test.moves)
+		if status, err := game.AgreeDraw(); err != nil || status != referee.Draw || game.GetReason() != referee.Agreement {
+			t.Errorf("%q: AgreeDraw() = %s, %v by %s, want draw by agreement", test.moves, status, err, game.GetReason())
+		}
+	}
+}
+
+func TestGameFromBoard(t *testing.T) {
+	if _, err := referee.GameFromBoard("......./......./......./......./......./xxxx..."); err != (referee.WonStart{}) {
+		t.Errorf("GameFromBoard() of a won board: error = %v, want WonStart", err)
+	}
+
+	// The first player moves on an even number of discs, whichever side has extra discs
+	tests := []struct {
+		board  string
+		win    referee.Status
+		resign referee.Status
+	}{
+		{"......./......./......./......./......./xxx.ooo", referee.FirstPlayerWin, referee.SecondPlayerWin},
+		{"......./......./......./......./......./xxx.oo.", referee.SecondPlayerWin, referee.FirstPlayerWin},
+	}
+	for _, test := range tests {
+		game, err := referee.GameFromBoard(test.board)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status, err := game.Play(3); err != nil || status != test.win {
+			t.Errorf("%s: Play(3) = %s, %v, want %s", test.board, status, err, test.win)
+		}
+		if game.GetMoves() != "4" {
+			t.Errorf("%s: GetMoves() = %q, want only the moves played after the board", test.board, game.GetMoves())
+		}
+
+		game, _ = referee.GameFromBoard(test.board)
+		if status, _ := game.Resign(); status != test.resign {
+			t.Errorf("%s: Resign() = %s, want %s", test.board, status, test.resign)
+		}
+	}
+}
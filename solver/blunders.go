@@ -0,0 +1,53 @@
+package solver
+
+import (
+	"cmp"
+
+	"github.com/YKhan142008/c4-solver/events"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Checks every `events.MovePlayed` published on a bus, and publishes `events.BlunderDetected`
+// on the same bus when the move turns a win into a draw or loss, or a draw into a loss.
+//
+// Moves are checked with weak solves on the publisher's goroutine, so early in a game each move
+// may take a while to be accepted.
+//
+// # Arguments
+//
+// * `bus`: The bus carrying the moves.
+// * `s`: The solver used to check moves. It must not be used concurrently elsewhere.
+//
+// # Returns
+//
+// A function which stops watching the bus.
+func WatchBlunders(bus *events.Bus, s *Solver) func() {
+	return bus.Subscribe(func(e events.Event) {
+		after := e.Position
+		if after.IsWonPosition() {
+			return
+		}
+		before := after
+		before.Unplay(e.Column)
+		if before.IsWonPosition() {
+			return
+		}
+
+		best := s.Solve(&before, true)
+		score := 0
+		if after.GetMoves() < position.BoardSize {
+			score = -s.Solve(&after, true)
+		}
+		// Only a change of outcome is a blunder, not a win or loss by fewer moves
+		if cmp.Compare(score, 0) < cmp.Compare(best, 0) {
+			bus.Publish(events.Event{
+				Kind:     events.BlunderDetected,
+				Position: before,
+				Column:   e.Column,
+				Weak:     true,
+				Score:    score,
+				Best:     best,
+			})
+		}
+	}, events.MovePlayed)
+}
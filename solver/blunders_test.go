@@ -0,0 +1,48 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/events"
+	"github.com/YKhan142008/c4-solver/referee"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+func TestWatchBlunders(t *testing.T) {
+	tests := []struct {
+		moves   string
+		col     int
+		blunder bool
+	}{
+		// Only the second column wins
+		{"4114223543234441571776", 0, true},
+		{"4114223543234441571776", 1, false},
+		// Every move loses, some faster than others
+		{"3662736222162677567374123173543", 0, false},
+		{"3662736222162677567374123173543", 3, false},
+	}
+	for _, test := range tests {
+		game, err := referee.GameFromMoves(test.moves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bus := events.NewBus()
+		game.SetEvents(bus)
+		stop := solver.WatchBlunders(bus, solver.NewSolver())
+		blunders, cancel := bus.Channel(1, events.BlunderDetected)
+
+		if _, err := game.Play(test.col); err != nil {
+			t.Fatal(err)
+		}
+		stop()
+		cancel()
+
+		e, got := <-blunders
+		if got != test.blunder {
+			t.Errorf("%s then column %d: blunder = %v, want %v", test.moves, test.col+1, got, test.blunder)
+		}
+		if got && (e.Column != test.col || e.Score >= 0 || e.Best <= 0) {
+			t.Errorf("%s then column %d: got %+v", test.moves, test.col+1, e)
+		}
+	}
+}
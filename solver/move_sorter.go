@@ -0,0 +1,36 @@
+package solver
+
+import "github.com/YKhan142008/c4-solver/position"
+
+// Orders up to `W` moves by score so the most promising moves are searched first.
+//
+// Moves are kept sorted by insertion. Moves added with equal scores are returned in reverse
+// insertion order, so callers add their preferred moves last.
+type MoveSorter struct {
+	size    int
+	entries [position.W]move_entry
+}
+
+type move_entry struct {
+	move  uint64
+	score int
+}
+
+// Adds a move with its score, keeping the entries sorted by ascending score.
+func (self *MoveSorter) Add(move uint64, score int) {
+	pos := self.size
+	self.size++
+	for ; pos > 0 && self.entries[pos-1].score > score; pos-- {
+		self.entries[pos] = self.entries[pos-1]
+	}
+	self.entries[pos] = move_entry{move, score}
+}
+
+// Removes and returns the highest scored move, or 0 once every move has been returned.
+func (self *MoveSorter) Next() uint64 {
+	if self.size == 0 {
+		return 0
+	}
+	self.size--
+	return self.entries[self.size].move
+}
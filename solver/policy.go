@@ -0,0 +1,52 @@
+package solver
+
+import "math"
+
+// Converts per-column scores into a probability distribution over the columns.
+//
+// Probabilities follow a softmax of the scores divided by `temperature`: low temperatures
+// concentrate on the best moves, high temperatures tend towards a uniform choice among
+// playable columns. A temperature of 0 splits the probability evenly between the best moves.
+//
+// # Arguments
+//
+// * `scores`: Scores as returned by `Solver.Analyze()`, with `InvalidMove` for full columns.
+// * `temperature`: A non-negative temperature.
+//
+// # Returns
+//
+// A slice of probabilities, one per column, summing to 1 unless every column is full.
+func Policy(scores []int, temperature float64) []float64 {
+	probabilities := make([]float64, len(scores))
+
+	best := InvalidMove
+	for _, score := range scores {
+		if score > best {
+			best = score
+		}
+	}
+	if best == InvalidMove {
+		return probabilities
+	}
+
+	// Shifts by the best score to keep the exponentials in range
+	var total float64 = 0
+	for col, score := range scores {
+		if score == InvalidMove {
+			continue
+		}
+		if temperature <= 0 {
+			if score == best {
+				probabilities[col] = 1
+			}
+		} else {
+			probabilities[col] = math.Exp(float64(score-best) / temperature)
+		}
+		total += probabilities[col]
+	}
+
+	for col := range probabilities {
+		probabilities[col] /= total
+	}
+	return probabilities
+}
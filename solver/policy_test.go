@@ -0,0 +1,34 @@
+package solver_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+func TestPolicy(t *testing.T) {
+	tests := []struct {
+		scores      []int
+		temperature float64
+		want        []float64
+	}{
+		// A temperature of 0 splits evenly between the best moves
+		{[]int{1, 3, x, 3, -2}, 0, []float64{0, 0.5, 0, 0.5, 0}},
+		// Equal scores are equally likely, whatever the temperature
+		{[]int{0, x, 0, 0}, 5, []float64{1.0 / 3, 0, 1.0 / 3, 1.0 / 3}},
+		{[]int{1, 0}, 1, []float64{math.E / (math.E + 1), 1 / (math.E + 1)}},
+		// Large score gaps must not overflow
+		{[]int{18, -18}, 0.01, []float64{1, 0}},
+		{[]int{x, x}, 1, []float64{0, 0}},
+	}
+	for _, test := range tests {
+		got := solver.Policy(test.scores, test.temperature)
+		for col := range test.want {
+			if math.Abs(got[col]-test.want[col]) > 1e-9 {
+				t.Errorf("Policy(%v, %v) = %v, want %v", test.scores, test.temperature, got, test.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package solver
+
+import "github.com/YKhan142008/c4-solver/position"
+
+// Computes how many moves remain until the end of the game when both players play perfectly.
+//
+// # Arguments
+//
+// * `moves`: The number of moves already played.
+// * `score`: The exact score of the position for the player to move.
+//
+// # Returns
+//
+// The number of moves left, counting the winning move, or the number of empty cells for
+// a draw.
+func PliesToEnd(moves int, score int) int {
+	if score == 0 {
+		return position.BoardSize - moves
+	}
+
+	// The winner plays the moves whose count of previous moves has the parity of `winner_moves`
+	winner_moves := moves
+	if score < 0 {
+		winner_moves = moves + 1
+		score = -score
+	}
+
+	// Inverts score = (BoardSize + 1 - previous moves) / 2 for the winner's parity
+	previous := position.BoardSize + 1 - 2*score
+	if (previous-winner_moves)%2 != 0 {
+		previous--
+	}
+	return previous + 1 - moves
+}
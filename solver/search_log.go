@@ -0,0 +1,81 @@
+package solver
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Search decisions at the root of each solve, written as a compact line based replay log.
+//
+// Each line starts with a one letter event followed by space separated fields:
+//
+// * `S <moves> <weak> <board>`: a solve starts. The board uses the format of
+// `position.PositionFromBoardString` without line breaks.
+// * `W <alpha> <beta>`: a search of the root with a new window starts.
+// * `O <col>...`: the order in which root moves are searched, as 1-based columns.
+// * `M <col> <score>`: a root move returned a score within or below the window.
+// * `C <col> <score>`: a root move returned a score at or above the window, cutting off the
+// remaining moves.
+// * `R <score>`: the search with the current window returned.
+// * `E <score> <nodes>`: the solve finished with its final score and total node count.
+//
+// Write errors are ignored, so logging never interrupts solving. The `search-replay` command of
+// `connect4` prints a log as a readable trace.
+
+func (self *Solver) log_solve(p *position.Position, weak bool) {
+	if self.search_log == nil {
+		return
+	}
+	self.root_moves = p.GetMoves()
+	self.root_nodes = self.node_count
+	fmt.Fprintf(self.search_log, "S %d %t %s\n", p.GetMoves(), weak, strings.ReplaceAll(p.String(), "\n", ""))
+}
+
+func (self *Solver) log_window(alpha int, beta int) {
+	if self.search_log != nil {
+		fmt.Fprintf(self.search_log, "W %d %d\n", alpha, beta)
+	}
+}
+
+func (self *Solver) log_order(moves *MoveSorter) {
+	var sb strings.Builder
+	sb.WriteString("O")
+	for i := moves.size - 1; i >= 0; i-- {
+		fmt.Fprintf(&sb, " %d", move_column(moves.entries[i].move)+1)
+	}
+	sb.WriteByte('\n')
+	self.search_log.Write([]byte(sb.String()))
+}
+
+func (self *Solver) log_move(move uint64, score int, cutoff bool) {
+	event := 'M'
+	if cutoff {
+		event = 'C'
+	}
+	fmt.Fprintf(self.search_log, "%c %d %d\n", event, move_column(move)+1, score)
+}
+
+func (self *Solver) log_result(score int) {
+	if self.search_log != nil {
+		fmt.Fprintf(self.search_log, "R %d\n", score)
+	}
+}
+
+func (self *Solver) log_end(score int) {
+	if self.search_log != nil {
+		fmt.Fprintf(self.search_log, "E %d %d\n", score, self.node_count-self.root_nodes)
+	}
+}
+
+// Indicates whether a node is the root of the current solve and its decisions are logged.
+func (self *Solver) is_logged_root(p *position.Position) bool {
+	return self.search_log != nil && p.GetMoves() == self.root_moves
+}
+
+// Returns the 0-based column of a move bitmask.
+func move_column(move uint64) int {
+	return bits.TrailingZeros64(move) / (position.H + 1)
+}
@@ -0,0 +1,321 @@
+package solver
+
+import (
+	"cmp"
+	"io"
+
+	"github.com/YKhan142008/c4-solver/events"
+	"github.com/YKhan142008/c4-solver/position"
+)
+
+// Scores are given from the point of view of the current player:
+//
+// * 0 for a draw.
+// * A positive score if the current player wins: 22 minus the number of their own discs on
+//   the board when they complete a 4-alignment, e.g. 1 for a win with their last disc.
+// * A negative score if the opponent wins, counted in the same way from the opponent's side.
+
+// The score reported for columns which cannot be played.
+const InvalidMove int = -1000
+
+type Solver struct {
+	node_count   uint64
+	table_probes uint64
+	table_hits   uint64
+	column_order [position.W]int
+	tt           *TranspositionTable
+
+	search_log io.Writer
+	events     *events.Bus
+	// The number of moves of the position being solved, and the node count when it started.
+	root_moves int
+	root_nodes uint64
+}
+
+type Options struct {
+	// The largest number of transposition table entries, rounded down to a valid size by
+	// `NewTranspositionTable()`.
+	// Defaults to `DefaultTableSize`.
+	TableSize int
+	// A hard cap on the memory used by the solver, in bytes. The transposition table is
+	// shrunk to fit, down to `MinTableSize` entries. 0 means no cap.
+	MaxMemoryBytes int
+	// Receives a replay log of the decisions taken at the root of each solve, see
+	// search_log.go. Nil disables logging.
+	SearchLog io.Writer
+	// Receives `events.SolveStarted` and `events.SolveFinished` for each solve. Nil disables
+	// publishing.
+	Events *events.Bus
+}
+
+// A snapshot of the solver's counters and memory use.
+type Stats struct {
+	// Nodes explored since the last `Reset()`.
+	Nodes uint64
+	// Transposition table lookups since the last `Reset()`, and those which found an entry.
+	TableProbes uint64
+	TableHits   uint64
+	// Transposition table entries.
+	TableSize int
+	// Memory allocated by the solver's caches, in bytes.
+	MemoryBytes int
+}
+
+// Creates a new `Solver` with the default options.
+func NewSolver() *Solver {
+	return NewSolverWithOptions(Options{})
+}
+
+// Creates a new `Solver` with an empty transposition table.
+func NewSolverWithOptions(options Options) *Solver {
+	if options.TableSize <= 0 {
+		options.TableSize = DefaultTableSize
+	}
+	if options.MaxMemoryBytes > 0 {
+		options.TableSize = min(options.TableSize, TableSizeForMemory(options.MaxMemoryBytes))
+	}
+	return &Solver{
+		tt:           NewTranspositionTable(options.TableSize),
+		column_order: position.CentreFirst(),
+		search_log:   options.SearchLog,
+		events:       options.Events,
+	}
+}
+
+// Returns the number of nodes explored since the last `Reset()`.
+func (self *Solver) GetNodeCount() uint64 {
+	return self.node_count
+}
+
+// Returns the number of transposition table lookups, and how many of them found an entry,
+// since the last `Reset()`.
+func (self *Solver) GetTableStats() (uint64, uint64) {
+	return self.table_probes, self.table_hits
+}
+
+// Returns the current counters and memory use.
+func (self *Solver) Stats() Stats {
+	return Stats{
+		Nodes:       self.node_count,
+		TableProbes: self.table_probes,
+		TableHits:   self.table_hits,
+		TableSize:   self.tt.Size(),
+		MemoryBytes: self.tt.MemoryBytes(),
+	}
+}
+
+// Lowers the memory cap of a running solver, shrinking the transposition table to fit down to
+// `MinTableSize` entries. Shrinking discards every table entry, so later solves are slower
+// until the table fills again, but the results are unchanged.
+//
+// # Arguments
+//
+// * `bytes`: The new cap in bytes. Caps above the current memory use have no effect.
+func (self *Solver) SetMemoryLimit(bytes int) {
+	if self.tt.MemoryBytes() <= bytes {
+		return
+	}
+	if size := TableSizeForMemory(bytes); size < self.tt.Size() {
+		self.tt = NewTranspositionTable(size)
+	}
+}
+
+// Clears the counters and the transposition table.
+func (self *Solver) Reset() {
+	self.node_count = 0
+	self.table_probes = 0
+	self.table_hits = 0
+	self.tt.Reset()
+}
+
+// Computes the exact score of a position, or only its sign if `weak` is set.
+//
+// # Arguments
+//
+// * `p`: A position which is not already won.
+// * `weak`: Only determines whether the position is a win, draw or loss, which is much faster.
+//
+// # Returns
+//
+// The score of the position. With `weak`, -1, 0 or 1.
+func (self *Solver) Solve(p *position.Position, weak bool) int {
+	self.log_solve(p, weak)
+	self.events.Publish(events.Event{Kind: events.SolveStarted, Position: *p, Column: -1, Weak: weak})
+	start := self.node_count
+
+	score := self.solve(p, weak)
+
+	self.log_end(score)
+	self.events.Publish(events.Event{
+		Kind:     events.SolveFinished,
+		Position: *p,
+		Column:   -1,
+		Weak:     weak,
+		Score:    score,
+		Nodes:    self.node_count - start,
+	})
+	return score
+}
+
+func (self *Solver) solve(p *position.Position, weak bool) int {
+	if p.CanWinNext() {
+		if weak {
+			return 1
+		}
+		return (position.BoardSize + 1 - p.GetMoves()) / 2
+	}
+
+	min := -(position.BoardSize - p.GetMoves()) / 2
+	max := (position.BoardSize + 1 - p.GetMoves()) / 2
+	if weak {
+		min = -1
+		max = 1
+	}
+
+	// Iteratively narrows the score range with null window searches
+	for min < max {
+		med := min + (max-min)/2
+		if med <= 0 && min/2 < med {
+			med = min / 2
+		} else if med >= 0 && max/2 > med {
+			med = max / 2
+		}
+		self.log_window(med, med+1)
+		r := self.negamax(p, med, med+1)
+		self.log_result(r)
+		if r <= med {
+			max = r
+		} else {
+			min = r
+		}
+	}
+	if weak {
+		// A search may prove a bound beyond the weak window, e.g. a win by 4, so only the sign
+		// of the result is kept
+		return cmp.Compare(min, 0)
+	}
+	return min
+}
+
+// Computes the score of playing each column of a position.
+//
+// # Arguments
+//
+// * `p`: A position which is not already won.
+// * `weak`: Only determines whether each move wins, draws or loses.
+//
+// # Returns
+//
+// A slice of `W` scores, one per column, with `InvalidMove` for full columns.
+func (self *Solver) Analyze(p *position.Position, weak bool) []int {
+	scores := make([]int, position.W)
+	for col := 0; col < position.W; col++ {
+		switch {
+		case !p.IsPlayable(col):
+			scores[col] = InvalidMove
+		case p.IsWinningMove(col):
+			if weak {
+				scores[col] = 1
+			} else {
+				scores[col] = (position.BoardSize + 1 - p.GetMoves()) / 2
+			}
+		default:
+			next := *p
+			next.Play(col)
+			scores[col] = -self.Solve(&next, weak)
+		}
+	}
+	return scores
+}
+
+// Recursively scores a position with alpha-beta pruning.
+//
+// Requires that the current player cannot win with their next move.
+//
+// # Returns
+//
+// * The exact score if it lies in ]`alpha`, `beta`[.
+// * An upper bound of the score lower than or equal to `alpha` if the score is at most `alpha`.
+// * A lower bound of the score greater than or equal to `beta` if the score is at least `beta`.
+func (self *Solver) negamax(p *position.Position, alpha int, beta int) int {
+	self.node_count++
+
+	next := p.PossibleNonLosingMoves()
+	if next == 0 {
+		// Every move lets the opponent win next turn
+		return -(position.BoardSize - p.GetMoves()) / 2
+	}
+
+	if p.GetMoves() >= position.BoardSize-2 {
+		// Neither player can win with the last two discs
+		return 0
+	}
+
+	// The opponent cannot win with their next move
+	min := -(position.BoardSize - 2 - p.GetMoves()) / 2
+	if alpha < min {
+		alpha = min
+		if alpha >= beta {
+			return alpha
+		}
+	}
+
+	// The current player cannot win with their next move
+	max := (position.BoardSize - 1 - p.GetMoves()) / 2
+
+	key := p.GetKey()
+	self.table_probes++
+	if val := int(self.tt.Get(key)); val != 0 {
+		self.table_hits++
+		if val > position.MaxScore-position.MinScore+1 {
+			min = val + 2*position.MinScore - position.MaxScore - 2
+			if alpha < min {
+				alpha = min
+				if alpha >= beta {
+					return alpha
+				}
+			}
+		} else {
+			max = val + position.MinScore - 1
+		}
+	}
+	if beta > max {
+		beta = max
+		if alpha >= beta {
+			return beta
+		}
+	}
+
+	var moves MoveSorter
+	for i := position.W - 1; i >= 0; i-- {
+		if move := next & position.ColumnMask(self.column_order[i]); move != 0 {
+			moves.Add(move, int(p.ScoreMove(move)))
+		}
+	}
+
+	logged := self.is_logged_root(p)
+	if logged {
+		self.log_order(&moves)
+	}
+
+	for move := moves.Next(); move != 0; move = moves.Next() {
+		child := *p
+		child.PlayMove(move)
+		score := -self.negamax(&child, -beta, -alpha)
+		if logged {
+			self.log_move(move, score, score >= beta)
+		}
+		if score >= beta {
+			// Saves a lower bound of the position
+			self.tt.Put(key, uint8(score+position.MaxScore-2*position.MinScore+2))
+			return score
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+
+	// Saves an upper bound of the position
+	self.tt.Put(key, uint8(alpha-position.MinScore+1))
+	return alpha
+}
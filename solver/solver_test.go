@@ -0,0 +1,104 @@
+package solver_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4quick"
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/generate"
+	"github.com/YKhan142008/c4-solver/position"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+const x = solver.InvalidMove
+
+func TestAnalyzeKnownPositions(t *testing.T) {
+	tests := []struct {
+		moves  string
+		strong []int
+		weak   []int
+	}{
+		{"4444443322211", []int{-14, -14, 15, x, 14, -14, -14}, []int{-1, -1, 1, x, 1, -1, -1}},
+		{"4114223543234441571776", []int{-10, 3, -10, x, -10, -10, -10}, []int{-1, 1, -1, x, -1, -1, -1}},
+		{"447746462647545252276336755", []int{-5, -2, -6, x, -5, -4, -5}, []int{-1, -1, -1, x, -1, -1, -1}},
+		// Weak solves used to return the bound proven by the search, -4 for the fourth column
+		{"3662736222162677567374123173543", []int{-5, x, x, -4, -5, x, x}, []int{-1, x, x, -1, -1, x, x}},
+	}
+	for _, test := range tests {
+		p := c4test.Moves(t, test.moves)
+		if got := solver.NewSolver().Analyze(p, false); !slices.Equal(got, test.strong) {
+			t.Errorf("Analyze(%s, false) = %v, want %v", test.moves, got, test.strong)
+		}
+		if got := solver.NewSolver().Analyze(p, true); !slices.Equal(got, test.weak) {
+			t.Errorf("Analyze(%s, true) = %v, want %v", test.moves, got, test.weak)
+		}
+	}
+}
+
+// Scores a position by exploring the whole game tree, as an oracle for late positions.
+func minimax(p *position.Position) int {
+	if p.GetMoves() == position.BoardSize {
+		return 0
+	}
+	if p.CanWinNext() {
+		return (position.BoardSize + 1 - p.GetMoves()) / 2
+	}
+	best := -position.BoardSize
+	for col := 0; col < position.W; col++ {
+		if p.IsPlayable(col) {
+			next := *p
+			next.Play(col)
+			best = max(best, -minimax(&next))
+		}
+	}
+	return best
+}
+
+func late(p *position.Position) bool {
+	return p.GetMoves() >= 30 && p.GetMoves() < position.BoardSize
+}
+
+func TestSolveMatchesMinimax(t *testing.T) {
+	s := solver.NewSolver()
+	c4quick.Check(t, func(g c4quick.Game) bool {
+		return s.Solve(g.Position, false) == minimax(g.Position)
+	}, &c4quick.Config{Count: 200, Seed: 1, Filters: []generate.Filter{late}})
+}
+
+func TestWeakSolveIsSignOfStrongSolve(t *testing.T) {
+	s := solver.NewSolver()
+	c4quick.Check(t, func(g c4quick.Game) bool {
+		strong := s.Solve(g.Position, false)
+		weak := s.Solve(g.Position, true)
+		return weak == min(max(strong, -1), 1)
+	}, &c4quick.Config{Count: 200, Seed: 1, Filters: []generate.Filter{func(p *position.Position) bool {
+		return p.GetMoves() >= 20 && p.GetMoves() < position.BoardSize
+	}}})
+}
+
+func TestSetMemoryLimitShrinksARunningSolver(t *testing.T) {
+	s := solver.NewSolverWithOptions(solver.Options{TableSize: 1 << 20})
+	positions := []string{"4444443322211", "4114223543234441571776", "447746462647545252276336755"}
+	var before [][]int
+	for _, moves := range positions {
+		before = append(before, s.Analyze(c4test.Moves(t, moves), false))
+	}
+
+	memory := s.Stats().MemoryBytes
+	s.SetMemoryLimit(2 * memory)
+	if got := s.Stats().MemoryBytes; got != memory {
+		t.Errorf("MemoryBytes = %d after raising the limit, want %d", got, memory)
+	}
+	limit := solver.MinTableSize * solver.TableEntryBytes
+	s.SetMemoryLimit(limit)
+	if got := s.Stats().MemoryBytes; got >= memory || got > limit {
+		t.Errorf("MemoryBytes = %d after lowering the limit to %d, was %d", got, limit, memory)
+	}
+
+	for i, moves := range positions {
+		if got := s.Analyze(c4test.Moves(t, moves), false); !slices.Equal(got, before[i]) {
+			t.Errorf("Analyze(%s) = %v after shrinking, was %v", moves, got, before[i])
+		}
+	}
+}
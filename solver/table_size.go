@@ -0,0 +1,6 @@
+//go:build amd64 || arm64
+
+package solver
+
+// The default number of entries: the smallest prime above 2^23, about 40MB.
+const DefaultTableSize int = 8388617
@@ -0,0 +1,7 @@
+//go:build !amd64 && !arm64
+
+package solver
+
+// The default number of entries: the smallest prime above 2^21, about 10MB.
+// Other architectures are mostly 32-bit or WebAssembly targets with a tighter memory budget.
+const DefaultTableSize int = 2097169
@@ -0,0 +1,99 @@
+package solver
+
+// A fixed size hash table storing bounds on the score of previously searched positions.
+//
+// Keys are truncated to their lowest 32 bits. Since the table size is a prime larger than
+// 2^17 and full keys fit in 49 bits, the (index, truncated key) pair identifies a position
+// unambiguously by the Chinese remainder theorem. Colliding entries are simply overwritten.
+
+// The smallest table size keeping truncated keys unambiguous: the smallest prime above 2^17.
+const MinTableSize int = 131101
+
+// The memory used by each entry: a truncated key and a value.
+const TableEntryBytes int = 5
+
+type TranspositionTable struct {
+	keys   []uint32
+	values []uint8
+}
+
+// Creates a new, empty `TranspositionTable`.
+//
+// # Arguments
+//
+// * `size`: The largest number of entries. Sizes which are not primes larger than 2^17 would
+// let different positions share an entry, so they are rounded down to the nearest such prime,
+// and up to `MinTableSize` if smaller.
+func NewTranspositionTable(size int) *TranspositionTable {
+	size = TableSizeAtMost(size)
+	return &TranspositionTable{
+		keys:   make([]uint32, size),
+		values: make([]uint8, size),
+	}
+}
+
+func (self *TranspositionTable) index(key uint64) int {
+	return int(key % uint64(len(self.keys)))
+}
+
+// Stores a value for a position key, overwriting any previous entry in its slot.
+func (self *TranspositionTable) Put(key uint64, value uint8) {
+	i := self.index(key)
+	self.keys[i] = uint32(key)
+	self.values[i] = value
+}
+
+// Returns the value stored for a position key, or 0 if the key is missing.
+func (self *TranspositionTable) Get(key uint64) uint8 {
+	i := self.index(key)
+	if self.keys[i] == uint32(key) {
+		return self.values[i]
+	}
+	return 0
+}
+
+// Returns the number of entries.
+func (self *TranspositionTable) Size() int {
+	return len(self.keys)
+}
+
+// Returns the memory allocated for the entries, in bytes.
+func (self *TranspositionTable) MemoryBytes() int {
+	return len(self.keys)*4 + len(self.values)
+}
+
+// Returns the largest table size, a prime, fitting in a memory budget. Budgets too small for
+// `MinTableSize` entries give `MinTableSize`.
+func TableSizeForMemory(bytes int) int {
+	return TableSizeAtMost(bytes / TableEntryBytes)
+}
+
+// Returns the largest valid table size, a prime, up to `size` entries. Sizes below
+// `MinTableSize` give `MinTableSize`.
+func TableSizeAtMost(size int) int {
+	if size <= MinTableSize {
+		return MinTableSize
+	}
+	for !is_prime(size) {
+		size--
+	}
+	return size
+}
+
+func is_prime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clears every entry of the table.
+func (self *TranspositionTable) Reset() {
+	clear(self.keys)
+	clear(self.values)
+}
@@ -0,0 +1,36 @@
+package solver_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/YKhan142008/c4-solver/c4test"
+	"github.com/YKhan142008/c4-solver/solver"
+)
+
+func TestTableSizeAtMost(t *testing.T) {
+	tests := []struct{ size, want int }{
+		{0, solver.MinTableSize},
+		{1 << 16, solver.MinTableSize},
+		{solver.MinTableSize, solver.MinTableSize},
+		{1 << 20, 1048573},
+		{1048573, 1048573},
+		{solver.DefaultTableSize, solver.DefaultTableSize},
+	}
+	for _, test := range tests {
+		if got := solver.TableSizeAtMost(test.size); got != test.want {
+			t.Errorf("TableSizeAtMost(%d) = %d, want %d", test.size, got, test.want)
+		}
+	}
+}
+
+func TestInvalidTableSizesGiveExactScores(t *testing.T) {
+	p := c4test.Moves(t, "447746462647545252276336755")
+	want := solver.NewSolver().Analyze(p, false)
+	for _, size := range []int{1 << 20, 1000, 1 << 17} {
+		s := solver.NewSolverWithOptions(solver.Options{TableSize: size})
+		if got := s.Analyze(p, false); !slices.Equal(got, want) {
+			t.Errorf("table size %d (%d entries): Analyze() = %v, want %v", size, s.Stats().TableSize, got, want)
+		}
+	}
+}
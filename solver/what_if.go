@@ -0,0 +1,67 @@
+package solver
+
+import "github.com/YKhan142008/c4-solver/position"
+
+// The outcome of playing a column, two plies deep.
+type WhatIf struct {
+	// 0-based index of the column played.
+	Column int
+	// The score of the move for the current player.
+	Score int
+	// 0-based index of the opponent's best reply, or -1 if the move ends the game.
+	Reply int
+	// The score of each of the opponent's replies from the opponent's point of view, with
+	// `InvalidMove` for full columns, or nil if the move ends the game.
+	ReplyScores []int
+}
+
+// Computes the opponent's best reply to every legal move of a position.
+//
+// Among equally good replies, the one closest to the centre is chosen.
+//
+// # Arguments
+//
+// * `p`: A position which is not already won.
+// * `weak`: Only determines whether each move wins, draws or loses.
+//
+// # Returns
+//
+// One `WhatIf` per playable column, ordered by column.
+func (self *Solver) WhatIf(p *position.Position, weak bool) []WhatIf {
+	var results []WhatIf
+	for col := 0; col < position.W; col++ {
+		if !p.IsPlayable(col) {
+			continue
+		}
+
+		result := WhatIf{Column: col, Reply: -1}
+		if p.IsWinningMove(col) {
+			if weak {
+				result.Score = 1
+			} else {
+				result.Score = (position.BoardSize + 1 - p.GetMoves()) / 2
+			}
+			results = append(results, result)
+			continue
+		}
+
+		child := *p
+		child.Play(col)
+		if child.GetMoves() == position.BoardSize {
+			results = append(results, result)
+			continue
+		}
+
+		result.ReplyScores = self.Analyze(&child, weak)
+		best := InvalidMove
+		for _, reply := range self.column_order {
+			if score := result.ReplyScores[reply]; score > best {
+				best = score
+				result.Reply = reply
+			}
+		}
+		result.Score = -best
+		results = append(results, result)
+	}
+	return results
+}
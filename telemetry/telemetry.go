@@ -0,0 +1,60 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Pluggable reporting of solver activity for capacity monitoring.
+//
+// Telemetry is disabled by default: callers use `Nop` unless an operator provides another
+// `Sink`. Records only describe the work done, never the positions themselves.
+
+// A completed solve request.
+type Solve struct {
+	// The number of moves played in the solved position.
+	Moves int  `json:"moves"`
+	Weak  bool `json:"weak"`
+	// The number of positions solved to answer the request, e.g. one per column for an analysis.
+	Positions int           `json:"positions"`
+	Nodes     uint64        `json:"nodes"`
+	Duration  time.Duration `json:"duration_ns"`
+	// Transposition table lookups, and the lookups which found an entry.
+	TableProbes uint64 `json:"table_probes"`
+	TableHits   uint64 `json:"table_hits"`
+	// The memory allocated by the solver's caches after the request, in bytes.
+	MemoryBytes int `json:"memory_bytes"`
+}
+
+// Receives telemetry records. Implementations must be safe for concurrent use.
+type Sink interface {
+	RecordSolve(solve Solve)
+}
+
+// Discards every record.
+type Nop struct{}
+
+func (Nop) RecordSolve(Solve) {}
+
+// Writes every record as a line of JSON.
+type JSONLines struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+}
+
+// Creates a new `JSONLines` sink writing to `w`.
+func NewJSONLines(w io.Writer) *JSONLines {
+	return &JSONLines{encoder: json.NewEncoder(w)}
+}
+
+// Records a solve. Write errors are ignored, so telemetry never interrupts solving.
+func (self *JSONLines) RecordSolve(solve Solve) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.encoder.Encode(struct {
+		Event string `json:"event"`
+		Solve
+	}{"solve", solve})
+}